@@ -2,11 +2,18 @@ package app
 
 import (
 	"inscription/chain/eth/core"
+	"inscription/chain/util"
+	"inscription/config"
+	"inscription/ethscription"
 	"inscription/feature"
+	"inscription/journal"
+	"time"
 )
 
 type App struct {
-	token *feature.Token
+	proxy   *core.Proxy
+	token   *feature.Token
+	journal *journal.Journal
 }
 
 func NewApp(rpcUrl string, timeout int64) *App {
@@ -17,6 +24,7 @@ func NewApp(rpcUrl string, timeout int64) *App {
 	}
 
 	return &App{
+		proxy: proxy,
 		token: feature.NewToken(proxy),
 	}
 }
@@ -29,6 +37,17 @@ func (a *App) TokenBalanceOf(privateKey string) (balance string, err error) {
 	return a.token.BalanceOf(account.Address)
 }
 
+// BalanceOf
+//
+//	@Description: balance of the address, wei
+//	@receiver a
+//	@param address
+//	@return balance
+//	@return err
+func (a *App) BalanceOf(address string) (balance string, err error) {
+	return a.token.BalanceOf(address)
+}
+
 // TokenBalanceOfAccount
 //
 //	@Description: get balance
@@ -39,13 +58,294 @@ func (a *App) TokenBalanceOfAccount(account *core.Account) (balance string, err
 	return a.token.BalanceOf(account.Address)
 }
 
-func (a *App) Transfer(account *core.Account, toAddress string, value string) (hash string, err error) {
-	gasLimit := "21000"
-	gasPrice := "30000000000" // in wei (30 gwei)
-	return a.token.Transfer(account.PrivateKey, gasPrice, gasLimit, "", value, toAddress, "")
+// Transfer
+//
+//	@Description: send eth from the account with the fee of the fee mode
+//	@receiver a
+//	@param account
+//	@param toAddress
+//	@param value wei
+//	@param fee
+//	@return hash
+//	@return err
+func (a *App) Transfer(account *core.Account, toAddress string, value string, fee *config.Fee) (hash string, err error) {
+	gasPrice, maxPriorityFeePerGas, err := a.GasFee(fee)
+	if err != nil {
+		return "", err
+	}
+	txSign, err := a.TransferTx(account, toAddress, value, gasPrice, config.DefaultEthGasLimit, maxPriorityFeePerGas)
+	if err != nil {
+		return "", err
+	}
+	return txSign.TxHex, nil
+}
+
+// TransferTx
+//
+//	@Description: send eth from the account, returns the signed tx
+//	@receiver a
+//	@param account
+//	@param toAddress
+//	@param value wei
+//	@param gasPrice gas price of legacy tx, max fee per gas of 1559 tx
+//	@param gasLimit
+//	@param maxPriorityFeePerGas empty for legacy tx
+//	@return *core.BuildTxResult
+//	@return error
+func (a *App) TransferTx(account *core.Account, toAddress string, value string, gasPrice string, gasLimit string, maxPriorityFeePerGas string) (*core.BuildTxResult, error) {
+	signer, err := account.Signer()
+	if err != nil {
+		return nil, err
+	}
+	return a.token.TransferBySigner(signer, gasPrice, gasLimit, maxPriorityFeePerGas, value, toAddress, "")
 }
 
-func (a *App) Inscribe(privateKey string, data string, gasPrice string, gasLimit string) (hash string, err error) {
+// Inscribe
+//
+//	@Description: send the inscription data to the recipient, the ethscription is created for it
+//	@receiver a
+//	@param privateKey
+//	@param to recipient, empty is the account itself
+//	@param data hex data
+//	@param gasPrice gas price of legacy tx, max fee per gas of 1559 tx
+//	@param gasLimit
+//	@param maxPriorityFeePerGas empty for legacy tx
+//	@return hash
+//	@return err
+func (a *App) Inscribe(privateKey string, to string, data string, gasPrice string, gasLimit string, maxPriorityFeePerGas string) (hash string, err error) {
+	txSign, err := a.InscribeTx(privateKey, to, data, gasPrice, gasLimit, maxPriorityFeePerGas)
+	if err != nil {
+		return "", err
+	}
+	return txSign.TxHex, nil
+}
+
+// InscribeTx
+//
+//	@Description: same as Inscribe, returns the signed tx
+//	@receiver a
+//	@return *core.BuildTxResult
+//	@return error
+func (a *App) InscribeTx(privateKey string, to string, data string, gasPrice string, gasLimit string, maxPriorityFeePerGas string) (*core.BuildTxResult, error) {
 	account, err := core.NewAccount().AccountWithPrivateKey(privateKey)
-	return a.token.Transfer(privateKey, gasPrice, gasLimit, "", "0", account.Address, data)
+	if err != nil {
+		return nil, err
+	}
+	if to == "" {
+		to = account.Address
+	} else if err = util.ValidateAddress(to); err != nil {
+		return nil, err
+	}
+	return a.token.TransferTx(privateKey, gasPrice, gasLimit, maxPriorityFeePerGas, "0", to, data)
+}
+
+// TransferEthscriptions
+//
+//	@Description: move the ethscriptions of the account to the recipient in one tx, the gas limit is estimated when empty
+//	@receiver a
+//	@param account
+//	@param to
+//	@param ids
+//	@param fee
+//	@param gasLimit
+//	@return *core.BuildTxResult
+//	@return error
+func (a *App) TransferEthscriptions(account *core.Account, to string, ids []string, fee *config.Fee, gasLimit string) (*core.BuildTxResult, error) {
+	if err := util.ValidateAddress(to); err != nil {
+		return nil, err
+	}
+	data, err := ethscription.TransferData(ids)
+	if err != nil {
+		return nil, err
+	}
+	if gasLimit == "" {
+		if gasLimit, err = a.EstimateGasLimit(account.Address, to, "0", data); err != nil {
+			return nil, err
+		}
+	}
+	gasPrice, maxPriorityFeePerGas, err := a.GasFee(fee)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := account.Signer()
+	if err != nil {
+		return nil, err
+	}
+	return a.token.TransferEthscriptions(signer, gasPrice, gasLimit, maxPriorityFeePerGas, to, ids)
+}
+
+// SendTransaction
+//
+//	@Description: sign the tx with the account and send it, an empty nonce is allocated by the nonce manager
+//	@receiver a
+//	@param account
+//	@param tx
+//	@return *core.BuildTxResult
+//	@return error
+func (a *App) SendTransaction(account *core.Account, tx *core.Transaction) (*core.BuildTxResult, error) {
+	signer, err := account.Signer()
+	if err != nil {
+		return nil, err
+	}
+	return a.token.SendTransaction(signer, tx)
+}
+
+// SignTransaction
+//
+//	@Description: sign the tx of the account without sending it
+//	@receiver a
+//	@param account
+//	@param tx
+//	@return *core.BuildTxResult
+//	@return error
+func (a *App) SignTransaction(account *core.Account, tx *core.Transaction) (*core.BuildTxResult, error) {
+	signer, err := account.Signer()
+	if err != nil {
+		return nil, err
+	}
+	return a.token.SignTransaction(signer, tx)
+}
+
+// SendSigned
+//
+//	@Description: send a tx signed earlier
+//	@receiver a
+//	@param txSign
+//	@return error
+func (a *App) SendSigned(txSign *core.BuildTxResult) error {
+	return a.proxy.SendTx(txSign.SignedTx)
+}
+
+// SetRateLimit
+//
+//	@Description: limit the requests sent to the rpc node
+//	@receiver a
+//	@param rps requests per second, 0 is unlimited
+func (a *App) SetRateLimit(rps float64) {
+	a.proxy.SetRateLimit(rps)
+}
+
+// SetBroadcast
+//
+//	@Description: send every tx to all the nodes of the pool at once
+//	@receiver a
+//	@param broadcast
+func (a *App) SetBroadcast(broadcast bool) {
+	pool := a.proxy.Pool()
+	if pool == nil {
+		if broadcast {
+			LogInfof("broadcast needs several rpc urls, the txs go to the only one")
+		}
+		return
+	}
+	pool.SetBroadcast(broadcast)
+	LogInfof("rpc pool of %d nodes, broadcast: %t", len(pool.Status()), broadcast)
+}
+
+// SetWebsocket
+//
+//	@Description: follow the new heads and the mempool through the websocket url instead of polling
+//	@receiver a
+//	@param url empty polls, unless the rpc url is a websocket one
+func (a *App) SetWebsocket(url string) {
+	a.proxy.SetWebsocket(url)
+	if ws := a.proxy.Websocket(); ws != "" {
+		LogInfof("following the chain through the websocket %s", ws)
+	}
+}
+
+// SetSubmission
+//
+//	@Description: send the txs of the app with the submitter of the submission
+//	@receiver a
+//	@param submission
+//	@return error
+func (a *App) SetSubmission(submission *config.Submission) error {
+	submitter, err := core.NewSubmitter(submission, a.proxy.RemoteRpcClient)
+	if err != nil {
+		return err
+	}
+	a.proxy.SetSubmitter(submitter)
+	if submission.Submitter != config.SubmitterPublic && submission.Submitter != "" {
+		LogInfof("txs are submitted to the relay %s as %s txs", submission.RelayUrl, submission.Submitter)
+	}
+	return nil
+}
+
+// EstimateGasLimit
+//
+//	@Description: gas limit of the tx estimated by the node, doubled when it carries data
+//	@receiver a
+//	@param from
+//	@param to
+//	@param value wei
+//	@param data hex data
+//	@return string
+//	@return error
+func (a *App) EstimateGasLimit(from string, to string, value string, data string) (string, error) {
+	raw, err := util.HexDecodeString(data)
+	if err != nil {
+		return "", err
+	}
+	return a.token.EstimateGasLimit(from, to, "", value, raw)
+}
+
+// Call
+//
+//	@Description: simulate the tx with eth_call against the pending state
+//	@receiver a
+//	@param from
+//	@param to
+//	@param value wei
+//	@param data hex data
+//	@return []byte return data
+//	@return error
+func (a *App) Call(from string, to string, value string, data string) ([]byte, error) {
+	raw, err := util.HexDecodeString(data)
+	if err != nil {
+		return nil, err
+	}
+	msg := core.NewCallMsg()
+	msg.SetFrom(from)
+	msg.SetTo(to)
+	msg.SetValue(value)
+	msg.SetData(raw)
+	return a.proxy.Call(msg)
+}
+
+// SetJournal
+//
+//	@Description: record every inscription sent by Mint in the journal
+//	@receiver a
+//	@param j nil records nothing
+func (a *App) SetJournal(j *journal.Journal) {
+	a.journal = j
+}
+
+// NewReceiptTracker
+//
+//	@Description: tracker of the receipts of the txs sent by the app
+//	@receiver a
+//	@param confirmations
+//	@param timeout second
+//	@return *core.ReceiptTracker
+func (a *App) NewReceiptTracker(confirmations int, timeout int) *core.ReceiptTracker {
+	return a.proxy.NewReceiptTracker(uint64(confirmations), time.Duration(timeout)*time.Second)
+}
+
+// ReplaceTx
+//
+//	@Description: speed up or cancel the pending tx sent by the account
+//	@receiver a
+//	@param account
+//	@param hash
+//	@param replacement
+//	@return *core.BuildTxResult
+//	@return error
+func (a *App) ReplaceTx(account *core.Account, hash string, replacement *feature.Replacement) (*core.BuildTxResult, error) {
+	signer, err := account.Signer()
+	if err != nil {
+		return nil, err
+	}
+	return a.token.ReplaceTx(signer, hash, replacement)
 }
@@ -0,0 +1,34 @@
+package app
+
+import (
+	"crypto/ecdsa"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"inscription/chain/eth/core"
+	"inscription/chain/eth/core/coretest"
+	"testing"
+)
+
+// newTestApp is an app on a fake node at the head 100
+func newTestApp(t *testing.T) (*App, *coretest.Node) {
+	node := coretest.NewNode(t, 100, 0)
+	a := NewApp(node.URL, 1)
+	if a == nil {
+		t.Fatal("the app can't reach the node")
+	}
+	t.Cleanup(a.proxy.Close)
+	return a, node
+}
+
+// newTestAccount is the account of a new key
+func newTestAccount(t *testing.T) (*core.Account, *ecdsa.PrivateKey) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := core.NewAccount().AccountWithPrivateKey(hexutil.Encode(crypto.FromECDSA(key)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return account, key
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"inscription/chain/eth/core"
+	"inscription/config"
+	"inscription/journal"
+	"strconv"
+	"time"
+)
+
+// DropItem is one inscription of a drop
+type DropItem struct {
+	Index    int    // position in the manifest, from 1
+	Name     string // the item in the progress
+	Data     string // hex data
+	To       string // recipient
+	GasLimit string // estimated when empty
+	Fee      *config.Fee
+}
+
+// Drop
+//
+//	@Description: send the items from the account one after the other, every sent tx is added to the tracker
+//	and recorded in the journal with its item. Once ctx is done no more item is sent.
+//	@receiver a
+//	@param ctx
+//	@param account
+//	@param items
+//	@param delay second between two items
+//	@param tracker
+//	@return hashes the hash of each sent item by name
+//	@return failed the number of items not sent
+func (a *App) Drop(ctx context.Context, account *core.Account, items []*DropItem, delay int, tracker *core.ReceiptTracker) (hashes map[string]string, failed int) {
+	hashes = make(map[string]string, len(items))
+	for i, item := range items {
+		if ctx.Err() != nil || (i > 0 && !sleepContext(ctx, time.Duration(delay)*time.Second)) {
+			return hashes, failed + len(items) - i
+		}
+
+		txSign, err := a.dropItem(account, item)
+		if err != nil {
+			LogErrorf("%d/%d item %s failed,reason: %s", i+1, len(items), item.Name, err)
+			failed++
+			continue
+		}
+		hashes[item.Name] = txSign.TxHex
+		tracker.Track(txSign)
+		LogInfof("%d/%d item %s sent to %s,hash: %s", i+1, len(items), item.Name, item.To, txSign.TxHex)
+	}
+	return hashes, failed
+}
+
+// dropItem sends the item with the fee of the item and a managed nonce
+func (a *App) dropItem(account *core.Account, item *DropItem) (*core.BuildTxResult, error) {
+	gasPrice, maxPriorityFeePerGas, err := a.GasFee(item.Fee)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit := item.GasLimit
+	if gasLimit == "" {
+		if gasLimit, err = a.EstimateGasLimit(account.Address, item.To, "0", item.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	tx := core.NewTransaction("", gasPrice, gasLimit, maxPriorityFeePerGas, item.To, "0", item.Data)
+	txSign, err := a.SendTransaction(account, tx)
+	if err != nil {
+		// the nonce is allocated by SendTransaction when the tx isn't built
+		nonce, _ := strconv.ParseUint(tx.Nonce, 10, 64)
+		a.record(func(j *journal.Journal) error {
+			return j.FailedItem(account.Address, item.Index, item.Name, nonce, txSign, err)
+		})
+		return nil, err
+	}
+	a.record(func(j *journal.Journal) error { return j.SentItem(item.Index, item.Name, txSign) })
+	return txSign, nil
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"inscription/chain/eth/core"
+	"sync"
+	"sync/atomic"
+)
+
+// Engine mints the jobs of several accounts in parallel
+type Engine struct {
+	app      *App
+	workers  int
+	pipeline int
+}
+
+// NewEngine
+//
+//	@Description:
+//	@receiver a
+//	@param workers the number of accounts minting at the same time
+//	@param pipeline the number of txs in flight of each account
+//	@return *Engine
+func (a *App) NewEngine(workers int, pipeline int) *Engine {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Engine{
+		app:      a,
+		workers:  workers,
+		pipeline: pipeline,
+	}
+}
+
+// Run
+//
+//	@Description: mint every job on the workers. Once ctx is done the jobs not started are skipped
+//	and the running ones stop after their txs in flight are sent.
+//	@receiver e
+//	@param ctx
+//	@param jobs
+//	@param tracker
+//	@return failed the number of inscriptions not sent
+func (e *Engine) Run(ctx context.Context, jobs []*MintJob, tracker *core.ReceiptTracker) (failed int) {
+	var failedCount atomic.Int64
+	var wg sync.WaitGroup
+	queue := make(chan *MintJob)
+	for w := 0; w < e.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				failedCount.Add(int64(e.app.Mint(ctx, job, tracker, e.pipeline)))
+			}
+		}()
+	}
+
+	for i, job := range jobs {
+		select {
+		case queue <- job:
+		case <-ctx.Done():
+			for _, skipped := range jobs[i:] {
+				failedCount.Add(int64(skipped.Times))
+				e.app.discardSigned(skipped, 0)
+			}
+			close(queue)
+			wg.Wait()
+			return int(failedCount.Load())
+		}
+	}
+	close(queue)
+	wg.Wait()
+	return int(failedCount.Load())
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"testing"
+)
+
+func TestEngineRun(t *testing.T) {
+	a, node := newTestApp(t)
+	var jobs []*MintJob
+	for i := 0; i < 4; i++ {
+		jobs = append(jobs, newTestJob(t, 3))
+	}
+
+	tracker := a.NewReceiptTracker(1, 1)
+	if failed := a.NewEngine(2, 2).Run(context.Background(), jobs, tracker); failed != 0 {
+		t.Fatalf("%d inscriptions failed", failed)
+	}
+	if len(tracker.Receipts()) != 12 {
+		t.Fatalf("%d txs tracked, want 12", len(tracker.Receipts()))
+	}
+	// the txs of an account are sent in the order of their nonces
+	signer := types.LatestSignerForChainID(big.NewInt(1337))
+	nonces := make(map[string]uint64)
+	for _, tx := range node.Sent() {
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tx.Nonce() != nonces[from.Hex()] {
+			t.Fatalf("%s sent nonce %d, want %d", from.Hex(), tx.Nonce(), nonces[from.Hex()])
+		}
+		nonces[from.Hex()]++
+	}
+	for _, job := range jobs {
+		if nonces[job.Account.Address] != 3 {
+			t.Fatalf("%s sent %d txs, want 3", job.Account.Address, nonces[job.Account.Address])
+		}
+	}
+
+	// a done ctx skips every job, the nonces signed ahead are freed
+	job := newTestJob(t, 2)
+	if err := a.PreSign(job, 2); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if failed := a.NewEngine(1, 1).Run(ctx, []*MintJob{job, newTestJob(t, 3)}, tracker); failed != 5 {
+		t.Fatalf("%d inscriptions failed, want 5", failed)
+	}
+	if len(node.Sent()) != 12 || len(job.Signed) != 0 {
+		t.Fatal("nothing should be sent after ctx is done")
+	}
+}
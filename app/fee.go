@@ -0,0 +1,69 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"inscription/config"
+	"math/big"
+)
+
+// GasFee
+//
+//	@Description: gas price and tip of the next tx, the auto mode estimates them from the latest block
+//	@receiver a
+//	@param fee
+//	@return gasPrice gas price of legacy tx, max fee per gas of 1559 tx
+//	@return maxPriorityFeePerGas empty for legacy tx
+//	@return err
+func (a *App) GasFee(fee *config.Fee) (gasPrice string, maxPriorityFeePerGas string, err error) {
+	if fee.FeeMode != config.FeeModeAuto {
+		return StaticGasFee(fee)
+	}
+	maxFeeCap, err := parseCap(fee.MaxFeeCap)
+	if err != nil {
+		return "", "", err
+	}
+	tipCap, err := parseCap(fee.PriorityFeeCap)
+	if err != nil {
+		return "", "", err
+	}
+	dynamicFee, err := a.proxy.SuggestDynamicFee(fee.FeeMultiplier, maxFeeCap, tipCap)
+	if err != nil {
+		return "", "", err
+	}
+	if dynamicFee.MaxFeePerGas.Cmp(dynamicFee.BaseFee) < 0 {
+		LogInfof("max fee cap %s is below the base fee %s, the tx waits until the base fee drops", dynamicFee.MaxFeePerGas, dynamicFee.BaseFee)
+	}
+	return dynamicFee.MaxFeePerGas.String(), dynamicFee.MaxPriorityFeePerGas.String(), nil
+}
+
+// StaticGasFee
+//
+//	@Description: gas price and tip of the fee modes set in the config, without a node
+//	@param fee
+//	@return gasPrice gas price of legacy tx, max fee per gas of 1559 tx
+//	@return maxPriorityFeePerGas empty for legacy tx
+//	@return err the auto mode needs a node
+func StaticGasFee(fee *config.Fee) (gasPrice string, maxPriorityFeePerGas string, err error) {
+	switch fee.FeeMode {
+	case config.FeeModeLegacy, "":
+		return fee.GasPrice, "", nil
+	case config.FeeModeDynamic:
+		return fee.GasPrice, fee.MaxPriorityFeePerGas, nil
+	case config.FeeModeAuto:
+		return "", "", errors.New("the auto fee mode needs a node")
+	default:
+		return "", "", fmt.Errorf("unknown fee mode %q", fee.FeeMode)
+	}
+}
+
+func parseCap(value string) (*big.Int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	limit, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid fee cap %s", value)
+	}
+	return limit, nil
+}
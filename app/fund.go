@@ -0,0 +1,162 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inscription/chain/eth/core"
+	"inscription/config"
+	"math/big"
+)
+
+// TransferPlan is one eth transfer of a distribution or a sweep
+type TransferPlan struct {
+	From                 *core.Account
+	To                   string
+	Value                *big.Int // wei
+	GasPrice             string   // gas price of legacy tx, max fee per gas of 1559 tx
+	MaxPriorityFeePerGas string
+}
+
+// GasCost
+//
+//	@Description: the most the transfer pays for gas
+//	@receiver p
+//	@return *big.Int
+func (p *TransferPlan) GasCost() *big.Int {
+	return transferGasCost(p.GasPrice)
+}
+
+func transferGasCost(gasPrice string) *big.Int {
+	price, _ := new(big.Int).SetString(gasPrice, 10)
+	gasLimit, _ := new(big.Int).SetString(config.DefaultEthGasLimit, 10)
+	return price.Mul(price, gasLimit)
+}
+
+// PlanDistribute
+//
+//	@Description: send the amount from the account to every address, with topUp only what each address lacks to reach the amount
+//	@receiver a
+//	@param from
+//	@param to
+//	@param amount wei
+//	@param topUp
+//	@param fee
+//	@return []*TransferPlan
+//	@return error when the balance of the account can't cover the plan
+func (a *App) PlanDistribute(from *core.Account, to []string, amount *big.Int, topUp bool, fee *config.Fee) ([]*TransferPlan, error) {
+	gasPrice, maxPriorityFeePerGas, err := a.GasFee(fee)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := new(big.Int).SetString(gasPrice, 10); !ok {
+		return nil, fmt.Errorf("invalid gas price %q", gasPrice)
+	}
+
+	var plans []*TransferPlan
+	total := big.NewInt(0)
+	for _, address := range to {
+		value := new(big.Int).Set(amount)
+		if topUp {
+			balance, err := a.balanceWei(address)
+			if err != nil {
+				return nil, fmt.Errorf("query the balance of %s failed: %w", address, err)
+			}
+			value.Sub(value, balance)
+		}
+		if value.Sign() <= 0 {
+			LogInfof("%s already holds the amount, skip it", address)
+			continue
+		}
+		plan := &TransferPlan{From: from, To: address, Value: value, GasPrice: gasPrice, MaxPriorityFeePerGas: maxPriorityFeePerGas}
+		total.Add(total, value).Add(total, plan.GasCost())
+		plans = append(plans, plan)
+	}
+	if len(plans) == 0 {
+		return nil, errors.New("nothing to distribute")
+	}
+
+	balance, err := a.balanceWei(from.Address)
+	if err != nil {
+		return nil, err
+	}
+	if balance.Cmp(total) < 0 {
+		return plans, fmt.Errorf("the balance %s of %s can't cover the distribution %s wei", balance, from.Address, total)
+	}
+	return plans, nil
+}
+
+// PlanSweep
+//
+//	@Description: send the whole balance minus the gas cost of every account to the address
+//	@receiver a
+//	@param from
+//	@param to
+//	@param fee
+//	@return []*TransferPlan
+//	@return error
+func (a *App) PlanSweep(from []*core.Account, to string, fee *config.Fee) ([]*TransferPlan, error) {
+	gasPrice, maxPriorityFeePerGas, err := a.GasFee(fee)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := new(big.Int).SetString(gasPrice, 10); !ok {
+		return nil, fmt.Errorf("invalid gas price %q", gasPrice)
+	}
+	gasCost := transferGasCost(gasPrice)
+
+	var plans []*TransferPlan
+	for _, account := range from {
+		balance, err := a.balanceWei(account.Address)
+		if err != nil {
+			return nil, fmt.Errorf("query the balance of %s failed: %w", account.Address, err)
+		}
+		value := balance.Sub(balance, gasCost)
+		if value.Sign() <= 0 {
+			LogInfof("the balance of %s can't cover the gas cost %s wei, skip it", account.Address, gasCost)
+			continue
+		}
+		plans = append(plans, &TransferPlan{From: account, To: to, Value: value, GasPrice: gasPrice, MaxPriorityFeePerGas: maxPriorityFeePerGas})
+	}
+	if len(plans) == 0 {
+		return nil, errors.New("nothing to sweep")
+	}
+	return plans, nil
+}
+
+// ExecuteTransfers
+//
+//	@Description: send the planned transfers one by one until ctx is done, every sent tx is added to the tracker
+//	@receiver a
+//	@param ctx
+//	@param plans
+//	@param tracker
+//	@return failed the number of transfers not sent
+func (a *App) ExecuteTransfers(ctx context.Context, plans []*TransferPlan, tracker *core.ReceiptTracker) (failed int) {
+	for i, plan := range plans {
+		if ctx.Err() != nil {
+			return failed + len(plans) - i
+		}
+		txSign, err := a.TransferTx(plan.From, plan.To, plan.Value.String(), plan.GasPrice, config.DefaultEthGasLimit, plan.MaxPriorityFeePerGas)
+		if err != nil {
+			LogErrorf("transfer %s wei from %s to %s failed,reason: %s", plan.Value, plan.From.Address, plan.To, err)
+			failed++
+			continue
+		}
+		tracker.Track(txSign)
+		LogInfof("transfer %s wei from %s to %s sent,hash: %s", plan.Value, plan.From.Address, plan.To, txSign.TxHex)
+	}
+	return failed
+}
+
+func (a *App) balanceWei(address string) (*big.Int, error) {
+	balanceStr, err := a.BalanceOf(address)
+	if err != nil {
+		return nil, err
+	}
+	balance, ok := new(big.Int).SetString(balanceStr, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid balance %s", balanceStr)
+	}
+	return balance, nil
+}
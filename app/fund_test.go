@@ -0,0 +1,76 @@
+package app
+
+import (
+	"inscription/chain/eth/core"
+	"inscription/config"
+	"math/big"
+	"testing"
+)
+
+func newTestFee(gasPrice string) *config.Fee {
+	fee := config.NewFee()
+	fee.GasPrice = gasPrice
+	return &fee
+}
+
+func TestPlanSweep(t *testing.T) {
+	a, node := newTestApp(t)
+	rich, _ := newTestAccount(t)
+	poor, _ := newTestAccount(t)
+	node.SetBalance(rich.Address, big.NewInt(1000000))
+	// the gas cost is 21000 * 10
+	node.SetBalance(poor.Address, big.NewInt(210000))
+
+	plans, err := a.PlanSweep([]*core.Account{rich, poor}, "0x1", newTestFee("10"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plans) != 1 || plans[0].From != rich || plans[0].Value.Int64() != 1000000-210000 {
+		t.Fatalf("unexpected plans %+v", plans)
+	}
+	if plans[0].GasCost().Int64() != 210000 || plans[0].GasPrice != "10" {
+		t.Fatalf("unexpected gas cost %s", plans[0].GasCost())
+	}
+
+	if _, err = a.PlanSweep([]*core.Account{poor}, "0x1", newTestFee("10")); err == nil {
+		t.Fatal("expect nothing to sweep")
+	}
+}
+
+func TestPlanDistribute(t *testing.T) {
+	a, node := newTestApp(t)
+	from, _ := newTestAccount(t)
+	to := []string{"0x0000000000000000000000000000000000000001", "0x0000000000000000000000000000000000000002", "0x0000000000000000000000000000000000000003"}
+	node.SetBalance(to[0], big.NewInt(400))
+	node.SetBalance(to[1], big.NewInt(1000))
+	node.SetBalance(to[2], big.NewInt(0))
+	amount := big.NewInt(1000)
+	// the gas cost is 21000 of every transfer
+	node.SetBalance(from.Address, big.NewInt(600+1000+2*21000))
+
+	plans, err := a.PlanDistribute(from, to, amount, true, newTestFee("1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plans) != 2 || plans[0].To != to[0] || plans[0].Value.Int64() != 600 || plans[1].To != to[2] || plans[1].Value.Int64() != 1000 {
+		t.Fatalf("unexpected top-up plans %+v", plans)
+	}
+
+	// without topUp everyone gets the amount, which the balance can't cover
+	plans, err = a.PlanDistribute(from, to, amount, false, newTestFee("1"))
+	if err == nil || len(plans) != 3 {
+		t.Fatalf("expect the balance to fall short of 3 transfers, got %d plans, %v", len(plans), err)
+	}
+	for _, plan := range plans {
+		if plan.Value.Cmp(amount) != 0 {
+			t.Fatalf("%s gets %s, want %s", plan.To, plan.Value, amount)
+		}
+	}
+
+	// everyone holds the amount already
+	node.SetBalance(to[0], amount)
+	node.SetBalance(to[2], amount)
+	if _, err = a.PlanDistribute(from, to, amount, true, newTestFee("1")); err == nil {
+		t.Fatal("expect nothing to distribute")
+	}
+}
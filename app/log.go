@@ -8,7 +8,7 @@ import (
 var logger = log.New(os.Stdout, "", log.LstdFlags)
 
 func LogInfo(args ...interface{}) {
-	logger.Println(args)
+	logger.Println(args...)
 }
 
 func LogInfof(template string, args ...interface{}) {
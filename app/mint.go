@@ -0,0 +1,394 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"inscription/chain/eth/core"
+	"inscription/chain/util"
+	"inscription/config"
+	"inscription/ethscription"
+	"inscription/journal"
+	"inscription/payload"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MintJob is the inscription loop of one account
+type MintJob struct {
+	Account  *core.Account
+	Data     string            // hex data
+	Payload  *payload.Template // renders the data of every inscription instead of Data when set
+	GasLimit string
+	Fee      *config.Fee
+	Times    int
+	Delay    int    // second
+	Value    string // wei sent with every tx, empty is 0
+	Indexes  []int  // indexes of the inscriptions when set, e.g. the ones an earlier run didn't make, otherwise 1 to Times
+
+	Signed     []*core.BuildTxResult // txs of the next inscriptions signed ahead, sent first without delay
+	Recipients []string              // recipient of each inscription in turn, one for all of them, empty is the account itself
+}
+
+// Recipient
+//
+//	@Description: the recipient of the ith inscription
+//	@receiver job
+//	@param i from 1
+//	@return string
+func (job *MintJob) Recipient(i int) string {
+	switch {
+	case len(job.Recipients) == 0:
+		return job.Account.Address
+	case len(job.Recipients) == 1:
+		return job.Recipients[0]
+	default:
+		return job.Recipients[(i-1)%len(job.Recipients)]
+	}
+}
+
+// Index
+//
+//	@Description: the index of the kth inscription of the job, it renders the payload and picks the recipient
+//	@receiver job
+//	@param k from 0
+//	@return int
+func (job *MintJob) Index(k int) int {
+	if k < len(job.Indexes) {
+		return job.Indexes[k]
+	}
+	return k + 1
+}
+
+// Mint
+//
+//	@Description: send the inscriptions of the job, every sent tx is added to the tracker.
+//	The nonces are allocated in order, up to pipeline txs are signed ahead at the same time and one goroutine sends them
+//	in the order of their nonces. A failed tx would leave a gap, so the txs after it are not sent and the nonce is read
+//	from the node again once none of them is left.
+//	Once ctx is done no more tx is started, the ones in flight are waited for and the txs signed ahead left are discarded.
+//	@receiver a
+//	@param ctx
+//	@param job
+//	@param tracker
+//	@param pipeline
+//	@return failed the number of inscriptions not sent
+func (a *App) Mint(ctx context.Context, job *MintJob, tracker *core.ReceiptTracker, pipeline int) (failed int) {
+	address := job.Account.Address
+	accuracyEth := decimal.New(1, 18)
+	started := 0 // the txs signed ahead before it are sent
+	defer func() { a.discardSigned(job, started) }()
+
+	balance, err := a.balance(job.Account)
+	if err != nil {
+		LogErrorf("%s query the balance failed，reason: %s", address, err)
+		return job.Times
+	}
+	if balance.IsZero() {
+		LogErrorf("%s has no balance, skip its %d inscriptions", address, job.Times)
+		return job.Times
+	}
+	LogInfof("%s starts %d inscriptions, the balance:%s", address, job.Times, balance.DivRound(accuracyEth, 4))
+
+	if pipeline < 1 {
+		pipeline = 1
+	}
+	s := &mintSender{app: a, job: job, tracker: tracker, queue: make(chan *mintTx, pipeline), done: make(chan struct{}), failedRound: -1}
+	go s.run()
+	defer func() {
+		close(s.queue)
+		<-s.done
+		s.settle()
+		failed += s.failed
+	}()
+
+	for k := 0; k < job.Times; k++ {
+		if s.settle() {
+			// the txs signed ahead after the failed one have the nonces of the old round
+			a.discardSigned(job, k)
+		}
+		i := job.Index(k)
+		signed := job.signed(k)
+		if ctx.Err() != nil || signed == nil && !sleepContext(ctx, time.Duration(job.Delay)*time.Second) {
+			return failed + job.Times - k
+		}
+
+		tx := &mintTx{i: i, round: s.round, txSign: signed, signed: make(chan struct{})}
+		if signed != nil {
+			tx.nonce = signed.SignedTx.Nonce()
+			close(tx.signed)
+		} else {
+			var data, gasPrice, maxPriorityFeePerGas string
+			if data, tx.nonce, gasPrice, maxPriorityFeePerGas, err = a.prepareMint(job, i); err != nil {
+				failed++
+				continue
+			}
+			unsigned := core.NewTransaction(strconv.FormatUint(tx.nonce, 10), gasPrice, job.GasLimit, maxPriorityFeePerGas, job.Recipient(i), job.value(), data)
+			go func() {
+				defer close(tx.signed)
+				tx.txSign, tx.err = a.SignTransaction(job.Account, unsigned)
+			}()
+		}
+
+		started = k + 1
+		s.queued.Add(1)
+		select {
+		case s.queue <- tx:
+		case <-ctx.Done():
+			// the nonce is handed out, the sender counts it as a failed tx
+			s.queued.Done()
+			s.fail(tx, ctx.Err(), false)
+			return failed + job.Times - k - 1
+		}
+	}
+	return failed
+}
+
+// mintTx is an inscription of Mint on its way to the node
+type mintTx struct {
+	i      int
+	nonce  uint64
+	round  int // the nonces of a round follow each other, a failed tx ends its round
+	txSign *core.BuildTxResult
+	err    error         // of the signing
+	signed chan struct{} // closed once txSign or err is set
+}
+
+// mintSender sends the txs of a Mint in the order of their nonces
+type mintSender struct {
+	app     *App
+	job     *MintJob
+	tracker *core.ReceiptTracker
+	queue   chan *mintTx
+	queued  sync.WaitGroup // the txs of the queue not handled yet
+	done    chan struct{}  // closed once the queue is closed and empty
+	round   int            // of the next nonce, only touched by Mint
+	failed  int            // read once done
+
+	lock        sync.Mutex
+	failedRound int    // the last round ended by a failed tx
+	failedNonce uint64 // of the tx which ended it
+	submitted   bool   // whether that tx was handed to the node
+}
+
+// run sends the txs of the queue until it's closed
+func (s *mintSender) run() {
+	defer close(s.done)
+	for tx := range s.queue {
+		<-tx.signed
+		s.send(tx)
+		s.queued.Done()
+	}
+}
+
+func (s *mintSender) send(tx *mintTx) {
+	address := s.job.Account.Address
+	s.lock.Lock()
+	ended := tx.round <= s.failedRound
+	s.lock.Unlock()
+	if ended {
+		// it would wait behind the gap of the failed nonce
+		s.fail(tx, errors.New("a lower nonce failed"), false)
+		return
+	}
+	if tx.err != nil {
+		s.fail(tx, tx.err, false)
+		return
+	}
+	if err := s.app.SendSigned(tx.txSign); err != nil {
+		s.fail(tx, err, true)
+		return
+	}
+
+	s.app.record(func(j *journal.Journal) error { return j.Sent(tx.i, tx.txSign) })
+	s.tracker.Track(tx.txSign)
+	if to := s.job.Recipient(tx.i); to != address {
+		LogInfof("%s %dth inscription sent to %s,hash: %s", address, tx.i, to, tx.txSign.TxHex)
+		return
+	}
+	LogInfof("%s %dth inscription sent,hash: %s", address, tx.i, tx.txSign.TxHex)
+}
+
+// fail records the tx which is not sent, the first failure of a round ends it
+func (s *mintSender) fail(tx *mintTx, err error, submitted bool) {
+	address := s.job.Account.Address
+	LogErrorf("%s %dth inscription failed,reason: %s", address, tx.i, err)
+	s.lock.Lock()
+	s.failed++
+	if tx.round > s.failedRound {
+		s.failedRound, s.failedNonce, s.submitted = tx.round, tx.nonce, submitted
+	}
+	s.lock.Unlock()
+
+	var txSign *core.BuildTxResult
+	if submitted {
+		txSign = tx.txSign
+	}
+	s.app.record(func(j *journal.Journal) error { return j.Failed(address, tx.i, tx.nonce, txSign, err) })
+}
+
+// settle starts a new round once a failed tx ended the current one: it waits until no tx of the round is left
+// so that the nonce read from the node again isn't handed out twice
+//
+//	@return bool whether the round is ended
+func (s *mintSender) settle() bool {
+	s.lock.Lock()
+	ended := s.failedRound == s.round
+	s.lock.Unlock()
+	if !ended {
+		return false
+	}
+	s.queued.Wait()
+	s.app.proxy.Nonces().Failed(s.job.Account.Address, s.failedNonce, s.submitted)
+	s.round++
+	return true
+}
+
+// PreSign
+//
+//	@Description: sign the next inscriptions of the job ahead, Mint sends them first without delay.
+//	Their nonces are allocated in order and their fees are the ones at the time of signing.
+//	@receiver a
+//	@param job
+//	@param n the number of inscriptions, up to the times of the job
+//	@return error
+func (a *App) PreSign(job *MintJob, n int) error {
+	address := job.Account.Address
+	for k := len(job.Signed); k < job.Times && len(job.Signed) < n; k++ {
+		i := job.Index(k)
+		data, nonce, gasPrice, maxPriorityFeePerGas, err := a.prepareMint(job, i)
+		if err != nil {
+			return fmt.Errorf("%s %dth inscription can't be signed ahead: %w", address, i, err)
+		}
+		tx := core.NewTransaction(strconv.FormatUint(nonce, 10), gasPrice, job.GasLimit, maxPriorityFeePerGas, job.Recipient(i), job.value(), data)
+		txSign, err := a.SignTransaction(job.Account, tx)
+		if err != nil {
+			a.proxy.Nonces().Release(address, nonce)
+			return fmt.Errorf("%s %dth inscription can't be signed ahead: %w", address, i, err)
+		}
+		job.Signed = append(job.Signed, txSign)
+	}
+	LogInfof("%s signed %d inscriptions ahead", address, len(job.Signed))
+	return nil
+}
+
+// DiscardSigned
+//
+//	@Description: drop the txs of the job signed ahead, e.g. when the run stops before the start. They're never sent
+//	so the nonces they took are read from the node again.
+//	@receiver a
+//	@param job
+func (a *App) DiscardSigned(job *MintJob) {
+	a.discardSigned(job, 0)
+}
+
+// discardSigned drops the txs of the job signed ahead from the kth one on
+func (a *App) discardSigned(job *MintJob, k int) {
+	if k >= len(job.Signed) {
+		return
+	}
+	LogInfof("%s discards %d inscriptions signed ahead and not sent", job.Account.Address, len(job.Signed)-k)
+	job.Signed = job.Signed[:k]
+	a.proxy.Nonces().Reset(job.Account.Address)
+}
+
+// signed is the tx of the kth inscription signed ahead, nil when it isn't
+func (job *MintJob) signed(k int) *core.BuildTxResult {
+	if k < len(job.Signed) {
+		return job.Signed[k]
+	}
+	return nil
+}
+
+// value is the wei sent with every tx
+func (job *MintJob) value() string {
+	if job.Value == "" {
+		return "0"
+	}
+	return job.Value
+}
+
+// prepareMint renders the data of the ith inscription, queries the balance and the fee and allocates its nonce
+func (a *App) prepareMint(job *MintJob, i int) (data string, nonce uint64, gasPrice string, maxPriorityFeePerGas string, err error) {
+	address := job.Account.Address
+
+	data = job.Data
+	if job.Payload != nil {
+		if data, err = renderPayload(job.Payload, i, address); err != nil {
+			LogErrorf("%s %dth inscription render the payload failed，reason: %s", address, i, err)
+			return
+		}
+	}
+
+	balance, err := a.balance(job.Account)
+	if err != nil {
+		LogErrorf("%s %dth inscription query the balance failed，reason: %s", address, i, err)
+		return
+	}
+	LogInfof("%s the balance:%s", address, balance.DivRound(decimal.New(1, 18), 4))
+
+	gasPrice, maxPriorityFeePerGas, err = a.GasFee(job.Fee)
+	if err != nil {
+		LogErrorf("%s %dth inscription get the gas fee failed，reason: %s", address, i, err)
+		return
+	}
+
+	nonce, err = a.proxy.Nonces().Next(address)
+	if err != nil {
+		LogErrorf("%s %dth inscription get the nonce failed，reason: %s", address, i, err)
+	}
+	return
+}
+
+// renderPayload renders the hex data of the ith inscription of the address, a data URI is validated
+func renderPayload(tmpl *payload.Template, i int, address string) (data string, err error) {
+	text, err := tmpl.Next(i, address)
+	if err != nil {
+		return "", err
+	}
+	if strings.HasPrefix(text, ethscription.Prefix) {
+		if err = ethscription.Validate(text); err != nil {
+			return "", fmt.Errorf("invalid data URI %s: %w", text, err)
+		}
+	}
+	return util.TextToHex(text), nil
+}
+
+// record writes to the journal of the app when it has one, a failed write is only logged
+func (a *App) record(write func(j *journal.Journal) error) {
+	if a.journal == nil {
+		return
+	}
+	if err := write(a.journal); err != nil {
+		LogErrorf("write the journal failed,reason: %s", err)
+	}
+}
+
+func (a *App) balance(account *core.Account) (decimal.Decimal, error) {
+	balanceStr, err := a.TokenBalanceOfAccount(account)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return decimal.NewFromString(balanceStr)
+}
+
+// sleepContext returns false when ctx is done before the duration
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if d <= 0 {
+		return true
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"inscription/config"
+	"testing"
+	"time"
+)
+
+func newTestJob(t *testing.T, times int) *MintJob {
+	account, _ := newTestAccount(t)
+	fee := config.NewFee()
+	fee.GasPrice = "1"
+	return &MintJob{Account: account, Data: "0x6869", GasLimit: "22000", Fee: &fee, Times: times}
+}
+
+func TestMintPreSigned(t *testing.T) {
+	a, node := newTestApp(t)
+	job := newTestJob(t, 3)
+	job.Delay = 60
+
+	if err := a.PreSign(job, 2); err != nil {
+		t.Fatal(err)
+	}
+	if len(job.Signed) != 2 || len(node.Sent()) != 0 {
+		t.Fatalf("%d txs signed and %d sent, want 2 and 0", len(job.Signed), len(node.Sent()))
+	}
+	for k, txSign := range job.Signed {
+		if txSign.SignedTx.Nonce() != uint64(k) || txSign.SignedTx.To().Hex() != job.Account.Address {
+			t.Fatalf("unexpected tx signed ahead %+v", txSign.SignedTx)
+		}
+	}
+
+	// the signed txs are sent at once, the delay comes before the next one
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		for len(node.Sent()) < 2 {
+			time.Sleep(5 * time.Millisecond)
+		}
+		cancel()
+	}()
+	tracker := a.NewReceiptTracker(1, 1)
+	if failed := a.Mint(ctx, job, tracker, 1); failed != 1 {
+		t.Fatalf("%d inscriptions failed, want 1", failed)
+	}
+	sent := node.Sent()
+	if len(sent) != 2 || sent[0].Hash() != job.Signed[0].SignedTx.Hash() || sent[1].Hash() != job.Signed[1].SignedTx.Hash() {
+		t.Fatal("the txs signed ahead should be sent first")
+	}
+	if len(tracker.Receipts()) != 2 {
+		t.Fatalf("%d txs tracked, want 2", len(tracker.Receipts()))
+	}
+}
+
+func TestMintDiscardsSigned(t *testing.T) {
+	a, node := newTestApp(t)
+	job := newTestJob(t, 3)
+	if err := a.PreSign(job, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	// stopped before the first tx
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if failed := a.Mint(ctx, job, a.NewReceiptTracker(1, 1), 1); failed != 3 {
+		t.Fatalf("%d inscriptions failed, want 3", failed)
+	}
+	if len(job.Signed) != 0 || len(node.Sent()) != 0 {
+		t.Fatal("the txs signed ahead should be discarded")
+	}
+	if nonce, err := a.proxy.Nonces().Next(job.Account.Address); err != nil || nonce != 0 {
+		t.Fatalf("the nonces of the discarded txs should be free, got %d, %v", nonce, err)
+	}
+
+	// interrupted before the start
+	job = newTestJob(t, 2)
+	if err := a.PreSign(job, 2); err != nil {
+		t.Fatal(err)
+	}
+	a.DiscardSigned(job)
+	if nonce, err := a.proxy.Nonces().Next(job.Account.Address); err != nil || nonce != 0 {
+		t.Fatalf("the nonces of the discarded txs should be free, got %d, %v", nonce, err)
+	}
+}
+
+func TestMintFailedNonce(t *testing.T) {
+	a, node := newTestApp(t)
+	job := newTestJob(t, 6)
+	// the send of nonce 1 times out while the txs after it are signed
+	node.FailSend(1, "i/o timeout")
+
+	tracker := a.NewReceiptTracker(1, 1)
+	failed := a.Mint(context.Background(), job, tracker, 3)
+	sent := node.Sent()
+	if failed < 1 || failed+len(sent) != 6 || len(tracker.Receipts()) != len(sent) {
+		t.Fatalf("%d failed and %d sent, want 6 in total", failed, len(sent))
+	}
+	// the nonce is read again only once the txs after the failed one are settled, no gap and no nonce twice
+	for k, tx := range sent {
+		if tx.Nonce() != uint64(k) {
+			t.Fatalf("the %dth tx sent has nonce %d", k, tx.Nonce())
+		}
+	}
+	if nonce, err := a.proxy.Nonces().Next(job.Account.Address); err != nil || nonce != uint64(len(sent)) {
+		t.Fatalf("next nonce %d, want %d, %v", nonce, len(sent), err)
+	}
+}
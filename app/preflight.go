@@ -0,0 +1,178 @@
+package app
+
+import (
+	"fmt"
+	"inscription/chain/eth/core"
+	"inscription/chain/util"
+	"inscription/config"
+	"inscription/payload"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// PreflightTx is a tx an account is about to send Count times
+type PreflightTx struct {
+	To       string
+	Value    string // wei, empty is 0
+	Data     string // hex data
+	GasLimit string // the raised estimate is taken when empty
+	Fee      *config.Fee
+	Count    int
+}
+
+// Preflight is the check of the txs of an account before they are sent
+type Preflight struct {
+	Account  string
+	Txs      int
+	Estimate uint64   // the largest gas estimate of the txs
+	GasLimit uint64   // the largest gas limit of the txs
+	Cost     *big.Int // the most the txs pay, the gas limit at the gas price or max fee plus the value of every tx
+	Balance  *big.Int
+	Err      error // a tx fails the simulation or its gas limit is below the estimate
+}
+
+// Shortfall
+//
+//	@Description: what the balance lacks to cover the worst case cost
+//	@receiver p
+//	@return *big.Int nil when the balance is enough
+func (p *Preflight) Shortfall() *big.Int {
+	if p.Cost == nil || p.Balance == nil || p.Balance.Cmp(p.Cost) >= 0 {
+		return nil
+	}
+	return new(big.Int).Sub(p.Cost, p.Balance)
+}
+
+// MintPreflight
+//
+//	@Description: the txs of the remaining inscriptions of the job, the first payload and recipient stand for all of them
+//	@param job
+//	@return []*PreflightTx
+//	@return error when the payload can't be rendered
+func MintPreflight(job *MintJob) ([]*PreflightTx, error) {
+	i := job.Index(0)
+	data := job.Data
+	if job.Payload != nil {
+		text, err := job.Payload.Render(payload.Vars{Index: i, Counter: 1, Address: job.Account.Address, Timestamp: time.Now().Unix()})
+		if err != nil {
+			return nil, err
+		}
+		data = util.TextToHex(text)
+	}
+	return []*PreflightTx{{
+		To:       job.Recipient(i),
+		Value:    job.Value,
+		Data:     data,
+		GasLimit: job.GasLimit,
+		Fee:      job.Fee,
+		Count:    job.Times,
+	}}, nil
+}
+
+// DropPreflight
+//
+//	@Description: one tx for every item of the drop
+//	@param items
+//	@return []*PreflightTx
+func DropPreflight(items []*DropItem) []*PreflightTx {
+	txs := make([]*PreflightTx, 0, len(items))
+	for _, item := range items {
+		txs = append(txs, &PreflightTx{To: item.To, Data: item.Data, GasLimit: item.GasLimit, Fee: item.Fee, Count: 1})
+	}
+	return txs
+}
+
+// Preflight
+//
+//	@Description: simulate every tx with eth_call and estimate its gas from the account with the real payload,
+//	then add up the worst case cost against the balance. The first failure stops the check.
+//	@receiver a
+//	@param account
+//	@param txs
+//	@return *Preflight
+func (a *App) Preflight(account string, txs []*PreflightTx) *Preflight {
+	p := &Preflight{Account: account, Cost: big.NewInt(0)}
+	var err error
+	if p.Balance, err = a.balanceWei(account); err != nil {
+		p.Err = fmt.Errorf("query the balance failed: %w", err)
+		return p
+	}
+
+	fees := make(map[*config.Fee]*big.Int)
+	for _, tx := range txs {
+		if tx.Count <= 0 {
+			continue
+		}
+		p.Txs += tx.Count
+		if p.Err = a.preflightTx(p, account, tx, fees); p.Err != nil {
+			return p
+		}
+	}
+	return p
+}
+
+// preflightTx checks one tx and adds its cost, the gas price of each fee is queried once
+func (a *App) preflightTx(p *Preflight, account string, tx *PreflightTx, fees map[*config.Fee]*big.Int) error {
+	value := tx.Value
+	if value == "" {
+		value = "0"
+	}
+	wei, ok := new(big.Int).SetString(value, 10)
+	if !ok || wei.Sign() < 0 {
+		return fmt.Errorf("invalid value %q, expect wei in decimal", tx.Value)
+	}
+	raw, err := util.HexDecodeString(tx.Data)
+	if err != nil {
+		return err
+	}
+	msg := core.NewCallMsg()
+	msg.SetFrom(account)
+	msg.SetTo(tx.To)
+	msg.SetValue(value)
+	msg.SetData(raw)
+
+	if _, err = a.proxy.Call(msg); err != nil {
+		return fmt.Errorf("the simulated call to %s failed: %w", tx.To, err)
+	}
+	estimate, err := a.proxy.EstimateGas(msg)
+	if err != nil {
+		return fmt.Errorf("estimate the gas of the call to %s failed: %w", tx.To, err)
+	}
+
+	if estimate > p.Estimate {
+		p.Estimate = estimate
+	}
+	limit := estimate
+	if len(raw) > 0 {
+		// the same raise as EstimateGasLimit
+		limit *= config.GasFactor
+	}
+	if tx.GasLimit != "" {
+		if limit, err = strconv.ParseUint(tx.GasLimit, 10, 64); err != nil {
+			return fmt.Errorf("invalid gas limit %s", tx.GasLimit)
+		}
+		if limit < estimate {
+			return fmt.Errorf("gas limit %d is below the estimate %d, the txs would run out of gas", limit, estimate)
+		}
+	}
+	if limit > p.GasLimit {
+		p.GasLimit = limit
+	}
+
+	gasPrice, ok := fees[tx.Fee]
+	if !ok {
+		price, _, err := a.GasFee(tx.Fee)
+		if err != nil {
+			return err
+		}
+		if gasPrice, ok = new(big.Int).SetString(price, 10); !ok {
+			return fmt.Errorf("invalid gas price %q", price)
+		}
+		fees[tx.Fee] = gasPrice
+	}
+	cost := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(limit))
+	cost.Add(cost, wei).Mul(cost, big.NewInt(int64(tx.Count)))
+	p.Cost.Add(p.Cost, cost)
+	return nil
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestPreflight(t *testing.T) {
+	a, node := newTestApp(t)
+	account, _ := newTestAccount(t)
+	fee := newTestFee("2")
+	txs := []*PreflightTx{
+		// the estimate of a tx with data is raised
+		{To: "0x1", Data: "0x6869", Fee: fee, Count: 3},
+		{To: "0x2", Value: "5", GasLimit: "30000", Fee: fee, Count: 1},
+		{To: "0x3", Fee: fee, Count: 0},
+	}
+	cost := int64(3*2*42000 + 2*30000 + 5)
+	node.SetBalance(account.Address, big.NewInt(cost))
+
+	p := a.Preflight(account.Address, txs)
+	if p.Err != nil {
+		t.Fatal(p.Err)
+	}
+	if p.Txs != 4 || p.Estimate != 21000 || p.GasLimit != 42000 || p.Cost.Int64() != cost || p.Shortfall() != nil {
+		t.Fatalf("unexpected preflight %+v", p)
+	}
+
+	node.SetBalance(account.Address, big.NewInt(cost-100))
+	if shortfall := a.Preflight(account.Address, txs).Shortfall(); shortfall == nil || shortfall.Int64() != 100 {
+		t.Fatalf("shortfall %s, want 100", shortfall)
+	}
+
+	tests := []struct {
+		name   string
+		revert string
+		gas    uint64
+		txs    []*PreflightTx
+		err    string
+	}{
+		{"gas limit below the estimate", "", 40000, txs[1:], "below the estimate 40000"},
+		{"simulation reverted", "sold out", 21000, txs[1:], "simulated call to 0x2 failed"},
+		{"invalid value", "", 21000, []*PreflightTx{{To: "0x2", Value: "0.5", Fee: fee, Count: 1}}, `invalid value "0.5"`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			node.SetCall(test.revert, test.gas)
+			p := a.Preflight(account.Address, test.txs)
+			if p.Err == nil || !strings.Contains(p.Err.Error(), test.err) {
+				t.Fatalf("error %v, want %q", p.Err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"inscription/chain/eth/core"
+	"text/tabwriter"
+)
+
+// PrintReceipts
+//
+//	@Description: print a table of the tracked txs of each account followed by the count of each status
+//	@param receipts
+func PrintReceipts(receipts []*core.TxReceipt) {
+	var accounts []string
+	groups := make(map[string][]*core.TxReceipt)
+	for _, receipt := range receipts {
+		if _, ok := groups[receipt.From]; !ok {
+			accounts = append(accounts, receipt.From)
+		}
+		groups[receipt.From] = append(groups[receipt.From], receipt)
+	}
+
+	logger.Println("============summary============")
+	for _, account := range accounts {
+		printAccountReceipts(account, groups[account])
+	}
+	if len(accounts) > 1 {
+		LogInfof("%d accounts in total, %s", len(accounts), countStatus(receipts))
+	}
+}
+
+func printAccountReceipts(account string, receipts []*core.TxReceipt) {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tHASH\tNONCE\tSTATUS\tBLOCK\tGAS USED")
+	for i, receipt := range receipts {
+		block := "-"
+		if receipt.BlockNumber > 0 {
+			block = fmt.Sprint(receipt.BlockNumber)
+		}
+		status := string(receipt.Status)
+		if receipt.Status == core.TxPending && receipt.Seen() {
+			status += " (in mempool)"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\t%d\n", i+1, receipt.Hash, receipt.Nonce, status, block, receipt.GasUsed)
+	}
+	w.Flush()
+
+	logger.Printf("account %s\n%s", account, buf.String())
+	LogInfof("account %s %s", account, countStatus(receipts))
+}
+
+func countStatus(receipts []*core.TxReceipt) string {
+	counts := make(map[core.TxStatus]int)
+	for _, receipt := range receipts {
+		counts[receipt.Status]++
+	}
+	return fmt.Sprintf("confirmed: %d, reverted: %d, pending: %d, dropped: %d",
+		counts[core.TxConfirmed], counts[core.TxReverted], counts[core.TxPending], counts[core.TxDropped])
+}
+
+// PrintItems
+//
+//	@Description: print the hash and the status of every item of a drop, the items not sent have none
+//	@param items
+//	@param hashes the hash of each sent item by name
+//	@param receipts
+func PrintItems(items []*DropItem, hashes map[string]string, receipts []*core.TxReceipt) {
+	byHash := make(map[string]*core.TxReceipt)
+	for _, receipt := range receipts {
+		for _, hash := range receipt.Hashes() {
+			byHash[hash] = receipt
+		}
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tITEM\tTO\tHASH\tSTATUS")
+	for _, item := range items {
+		hash, status := "-", "not sent"
+		if sent, ok := hashes[item.Name]; ok {
+			hash, status = sent, string(core.TxPending)
+			if receipt, ok := byHash[sent]; ok {
+				// a speed up changes the hash
+				hash, status = receipt.Hash, string(receipt.Status)
+			}
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", item.Index, item.Name, item.To, hash, status)
+	}
+	w.Flush()
+	logger.Printf("============items============\n%s", buf.String())
+}
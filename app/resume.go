@@ -0,0 +1,66 @@
+package app
+
+import (
+	"inscription/chain/eth/core"
+	"inscription/journal"
+)
+
+// slot is a nonce of an account with every tx the journal recorded for it
+type slot struct {
+	receipt *core.TxReceipt
+	sent    bool // one of the txs was accepted by the node
+}
+
+// Reconcile
+//
+//	@Description: query the chain for the txs of the journal entries, one receipt for each nonce of each account.
+//	A tx which failed to be sent stays pending while the node knows it, e.g. after a timeout, it's dropped when the node
+//	doesn't have it or another tx took its nonce.
+//	@receiver a
+//	@param entries the entries of a run
+//	@return []*core.TxReceipt
+//	@return error
+func (a *App) Reconcile(entries []*journal.Entry) ([]*core.TxReceipt, error) {
+	var slots []*slot
+	byNonce := make(map[string]map[uint64]*slot)
+	for _, entry := range entries {
+		if entry.Kind != journal.KindTx || entry.Hash == "" {
+			continue
+		}
+		if byNonce[entry.Account] == nil {
+			byNonce[entry.Account] = make(map[uint64]*slot)
+		}
+		s, ok := byNonce[entry.Account][entry.Nonce]
+		if !ok {
+			s = &slot{receipt: &core.TxReceipt{Hash: entry.Hash, From: entry.Account, Nonce: entry.Nonce, Status: core.TxPending}}
+			byNonce[entry.Account][entry.Nonce] = s
+			slots = append(slots, s)
+		} else if s.receipt.Hash != entry.Hash {
+			// the latest tx of the nonce goes first
+			s.receipt.Replaced = append(s.receipt.Replaced, s.receipt.Hash)
+			s.receipt.Hash = entry.Hash
+		}
+		if entry.Status == journal.StatusSent {
+			s.sent = true
+		}
+	}
+
+	receipts := make([]*core.TxReceipt, 0, len(slots))
+	for _, s := range slots {
+		if err := a.proxy.UpdateReceipt(s.receipt, 1); err != nil {
+			return nil, err
+		}
+		if !s.receipt.Settled() && !s.sent {
+			known, err := a.proxy.Known(s.receipt)
+			if err != nil {
+				return nil, err
+			}
+			if !known {
+				// the nonce is free, the tx can't be mined
+				s.receipt.Status = core.TxDropped
+			}
+		}
+		receipts = append(receipts, s.receipt)
+	}
+	return receipts, nil
+}
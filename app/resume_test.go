@@ -0,0 +1,85 @@
+package app
+
+import (
+	"inscription/chain/eth/core"
+	"inscription/chain/eth/core/coretest"
+	"inscription/journal"
+	"testing"
+)
+
+func TestReconcile(t *testing.T) {
+	a, node := newTestApp(t)
+	account, key := newTestAccount(t)
+	other, otherKey := newTestAccount(t)
+
+	confirmed := coretest.SignTx(t, key, 0, 1)
+	reverted := coretest.SignTx(t, key, 1, 1)
+	replaced := coretest.SignTx(t, key, 2, 1)
+	speedUp := coretest.SignTx(t, key, 2, 2)
+	pending := coretest.SignTx(t, key, 3, 1)
+	failed := coretest.SignTx(t, key, 4, 1)
+	accepted := coretest.SignTx(t, key, 6, 1)
+	taken := coretest.SignTx(t, otherKey, 0, 1)
+
+	// the speed up of the 3rd tx isn't mined, the tx it replaced is
+	node.Revert(reverted.Hash())
+	node.Include(confirmed, reverted, replaced)
+	node.Mine()
+	node.Include(pending, accepted)
+	// the nonce of the other account is taken by a tx the journal doesn't have
+	node.SetNonce(other.Address, 1)
+
+	sent := func(address string, nonce uint64, hash string) *journal.Entry {
+		return &journal.Entry{Kind: journal.KindTx, Account: address, Nonce: nonce, Hash: hash, Status: journal.StatusSent}
+	}
+	entries := []*journal.Entry{
+		{Kind: journal.KindJob, Account: account.Address, Times: 6},
+		sent(account.Address, 0, confirmed.Hash().Hex()),
+		sent(account.Address, 1, reverted.Hash().Hex()),
+		sent(account.Address, 2, replaced.Hash().Hex()),
+		sent(account.Address, 2, speedUp.Hash().Hex()),
+		sent(account.Address, 3, pending.Hash().Hex()),
+		// the node never got it
+		{Kind: journal.KindTx, Account: account.Address, Nonce: 4, Hash: failed.Hash().Hex(), Status: journal.StatusFailed, Error: "timeout"},
+		// the send timed out but the node got it
+		{Kind: journal.KindTx, Account: account.Address, Nonce: 6, Hash: accepted.Hash().Hex(), Status: journal.StatusFailed, Error: "i/o timeout"},
+		// it failed before it was signed
+		{Kind: journal.KindTx, Account: account.Address, Nonce: 5, Status: journal.StatusFailed, Error: "insufficient funds"},
+		sent(other.Address, 0, taken.Hash().Hex()),
+	}
+	receipts, err := a.Reconcile(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		from     string
+		nonce    uint64
+		hash     string
+		replaced int
+		status   core.TxStatus
+	}{
+		{"mined", account.Address, 0, confirmed.Hash().Hex(), 0, core.TxConfirmed},
+		{"mined and failed", account.Address, 1, reverted.Hash().Hex(), 0, core.TxReverted},
+		{"replaced tx mined", account.Address, 2, replaced.Hash().Hex(), 1, core.TxConfirmed},
+		{"in the mempool", account.Address, 3, pending.Hash().Hex(), 0, core.TxPending},
+		{"never sent", account.Address, 4, failed.Hash().Hex(), 0, core.TxDropped},
+		{"failed but in the mempool", account.Address, 6, accepted.Hash().Hex(), 0, core.TxPending},
+		{"nonce taken", other.Address, 0, taken.Hash().Hex(), 0, core.TxDropped},
+	}
+	if len(receipts) != len(tests) {
+		t.Fatalf("%d receipts, want %d", len(receipts), len(tests))
+	}
+	for k, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			receipt := receipts[k]
+			if receipt.From != test.from || receipt.Nonce != test.nonce || receipt.Hash != test.hash || len(receipt.Replaced) != test.replaced {
+				t.Fatalf("unexpected receipt %+v", receipt)
+			}
+			if receipt.Status != test.status {
+				t.Fatalf("status %s, want %s", receipt.Status, test.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// Schedule is when a mint starts and stops
+type Schedule struct {
+	StartBlock uint64    // the txs are sent once the head is the block before, 0 doesn't wait for a block
+	StartTime  time.Time // zero doesn't wait
+	EndBlock   uint64    // no more tx is sent once the head reaches it, 0 never stops
+	MaxSupply  *big.Int  // no more tx is sent once Supply reaches it, nil never stops
+
+	Supply func() (*big.Int, error) // the current supply
+}
+
+// WaitStart
+//
+//	@Description: wait until the start time, or until the head is the block before the start block so that
+//	the txs sent next can land in it
+//	@receiver a
+//	@param ctx
+//	@param schedule
+//	@return error ctx.Err() when it's done first
+func (a *App) WaitStart(ctx context.Context, schedule *Schedule) error {
+	if !schedule.StartTime.IsZero() {
+		if wait := time.Until(schedule.StartTime); wait > 0 {
+			LogInfof("waiting %s until %s to start", wait.Round(time.Second), schedule.StartTime.Format(time.RFC3339))
+			if !sleepContext(ctx, wait) {
+				return ctx.Err()
+			}
+		}
+		return nil
+	}
+	if schedule.StartBlock == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	LogInfof("waiting for the block %d to start", schedule.StartBlock)
+	for head := range a.proxy.Heads(ctx) {
+		if number := head.Number.Uint64(); number+1 >= schedule.StartBlock {
+			LogInfof("the head is %d, start", number)
+			return nil
+		}
+	}
+	return ctx.Err()
+}
+
+// StopWhen
+//
+//	@Description: a ctx done once the head reaches the end block or the supply reaches the max supply,
+//	checked at every new head
+//	@receiver a
+//	@param ctx
+//	@param schedule
+//	@return context.Context
+//	@return context.CancelFunc
+func (a *App) StopWhen(ctx context.Context, schedule *Schedule) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	if schedule.EndBlock == 0 && schedule.MaxSupply == nil {
+		return ctx, cancel
+	}
+	go func() {
+		for head := range a.proxy.Heads(ctx) {
+			if reason := schedule.stopReason(head.Number.Uint64()); reason != "" {
+				LogInfof("stop sending the inscriptions, %s", reason)
+				cancel()
+				return
+			}
+		}
+	}()
+	return ctx, cancel
+}
+
+// stopReason is why the mint stops at the head, empty when it goes on
+func (s *Schedule) stopReason(head uint64) string {
+	if s.EndBlock > 0 && head >= s.EndBlock {
+		return fmt.Sprintf("the head %d reached the end block %d", head, s.EndBlock)
+	}
+	if s.MaxSupply == nil || s.Supply == nil {
+		return ""
+	}
+	supply, err := s.Supply()
+	if err != nil {
+		LogErrorf("query the supply failed,reason: %s", err)
+		return ""
+	}
+	if supply.Cmp(s.MaxSupply) >= 0 {
+		return fmt.Sprintf("the supply %s reached the max supply %s", supply, s.MaxSupply)
+	}
+	return ""
+}
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStopReason(t *testing.T) {
+	supply := func(value int64, err error) func() (*big.Int, error) {
+		return func() (*big.Int, error) { return big.NewInt(value), err }
+	}
+	tests := []struct {
+		name     string
+		schedule Schedule
+		head     uint64
+		stop     bool
+	}{
+		{"no end", Schedule{}, 100, false},
+		{"before the end block", Schedule{EndBlock: 101}, 100, false},
+		{"end block", Schedule{EndBlock: 101}, 101, true},
+		{"after the end block", Schedule{EndBlock: 101}, 102, true},
+		{"below the max supply", Schedule{MaxSupply: big.NewInt(10), Supply: supply(9, nil)}, 100, false},
+		{"max supply", Schedule{MaxSupply: big.NewInt(10), Supply: supply(10, nil)}, 100, true},
+		{"supply unknown", Schedule{MaxSupply: big.NewInt(10), Supply: supply(0, errors.New("timeout"))}, 100, false},
+		{"end block before the supply", Schedule{EndBlock: 100, MaxSupply: big.NewInt(10), Supply: supply(0, nil)}, 100, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if reason := test.schedule.stopReason(test.head); (reason != "") != test.stop {
+				t.Fatalf("stop reason %q, want a stop %v", reason, test.stop)
+			}
+		})
+	}
+}
+
+func TestWaitStart(t *testing.T) {
+	a, node := newTestApp(t)
+
+	// the head is the block before the start block
+	if err := a.WaitStart(context.Background(), &Schedule{StartBlock: 101}); err != nil {
+		t.Fatal(err)
+	}
+	begin := time.Now()
+	if err := a.WaitStart(context.Background(), &Schedule{StartTime: begin.Add(50 * time.Millisecond)}); err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(begin) < 50*time.Millisecond {
+		t.Fatal("it should wait for the start time")
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- a.WaitStart(context.Background(), &Schedule{StartBlock: 102}) }()
+	select {
+	case err := <-done:
+		t.Fatalf("it should wait for the head 101, got %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	node.Mine()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("it should start at the head 101")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := a.WaitStart(ctx, &Schedule{StartBlock: 200}); err == nil {
+		t.Fatal("expect an error when ctx is done first")
+	}
+}
+
+func TestStopWhen(t *testing.T) {
+	a, node := newTestApp(t)
+
+	ctx, cancel := a.StopWhen(context.Background(), &Schedule{})
+	cancel()
+	ctx, cancel = a.StopWhen(context.Background(), &Schedule{EndBlock: 101})
+	defer cancel()
+	select {
+	case <-ctx.Done():
+		t.Fatal("it shouldn't stop before the end block")
+	case <-time.After(100 * time.Millisecond):
+	}
+	node.Mine()
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("it should stop at the end block")
+	}
+
+	var supply atomic.Int64
+	ctx, cancel = a.StopWhen(context.Background(), &Schedule{MaxSupply: big.NewInt(1), Supply: func() (*big.Int, error) { return big.NewInt(supply.Load()), nil }})
+	defer cancel()
+	supply.Store(1)
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("it should stop at the max supply")
+	}
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"github.com/ethereum/go-ethereum/core/types"
+	"inscription/chain/eth/core"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// SignJob
+//
+//	@Description: sign the inscriptions of the job without a node, the nonces go on from the first one
+//	@param job
+//	@param chainId
+//	@param nonce the nonce of the first inscription
+//	@param gasPrice wei, the max fee per gas of 1559 txs
+//	@param maxPriorityFeePerGas wei, empty signs legacy txs
+//	@return []*core.BuildTxResult
+//	@return error
+func SignJob(job *MintJob, chainId *big.Int, nonce uint64, gasPrice string, maxPriorityFeePerGas string) ([]*core.BuildTxResult, error) {
+	signer, err := job.Account.Signer()
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]*core.BuildTxResult, 0, job.Times)
+	for k := 0; k < job.Times; k++ {
+		i := job.Index(k)
+		data := job.Data
+		if job.Payload != nil {
+			if data, err = renderPayload(job.Payload, i, job.Account.Address); err != nil {
+				return nil, fmt.Errorf("%dth inscription: %w", i, err)
+			}
+		}
+		tx := core.NewTransaction(strconv.FormatUint(nonce, 10), gasPrice, job.GasLimit, maxPriorityFeePerGas, job.Recipient(i), job.value(), data)
+		txSign, err := core.SignOffline(signer, tx, chainId)
+		if err != nil {
+			return nil, fmt.Errorf("%dth inscription: %w", i, err)
+		}
+		txs = append(txs, txSign)
+		nonce++
+	}
+	return txs, nil
+}
+
+// ChainID
+//
+//	@Description: the chain id of the node
+//	@receiver a
+//	@return *big.Int
+func (a *App) ChainID() *big.Int {
+	return a.proxy.ChainID()
+}
+
+// Nonce
+//
+//	@Description: the pending nonce of the address
+//	@receiver a
+//	@param address
+//	@return uint64
+//	@return error
+func (a *App) Nonce(address string) (uint64, error) {
+	return a.proxy.Nonce(address)
+}
+
+// Broadcast
+//
+//	@Description: send the signed txs in order, every sent tx is added to the tracker. Once ctx is done no more tx is sent.
+//	@receiver a
+//	@param ctx
+//	@param txs
+//	@param delay between two txs, 0 sends them at once
+//	@param tracker
+//	@return failed the number of txs not sent
+func (a *App) Broadcast(ctx context.Context, txs []*types.Transaction, delay time.Duration, tracker *core.ReceiptTracker) (failed int) {
+	for i, tx := range txs {
+		if i > 0 && !sleepContext(ctx, delay) || ctx.Err() != nil {
+			return failed + len(txs) - i
+		}
+		from, err := a.proxy.Sender(tx)
+		if err != nil {
+			LogErrorf("%dth tx has no valid signature,reason: %s", i+1, err)
+			failed++
+			continue
+		}
+		txSign := &core.BuildTxResult{SignedTx: tx, TxHex: tx.Hash().String(), From: from}
+		if err = a.SendSigned(txSign); err != nil {
+			LogErrorf("%s %dth tx with nonce %d failed,reason: %s", from, i+1, tx.Nonce(), err)
+			failed++
+			continue
+		}
+		tracker.Track(txSign)
+		LogInfof("%s %dth tx with nonce %d sent,hash: %s", from, i+1, tx.Nonce(), txSign.TxHex)
+	}
+	return failed
+}
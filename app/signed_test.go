@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"inscription/chain/eth/core/coretest"
+	"inscription/config"
+	"math/big"
+	"testing"
+)
+
+func TestSignJob(t *testing.T) {
+	job := newTestJob(t, 3)
+	to := []string{"0x0000000000000000000000000000000000000001", "0x0000000000000000000000000000000000000002", "0x0000000000000000000000000000000000000003"}
+	job.Recipients = to
+	// the 1st inscription was made by an earlier run
+	job.Indexes = []int{2, 3, 5}
+
+	txs, err := SignJob(job, big.NewInt(5), 7, "30", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{to[1], to[2], to[1]}
+	for k, txSign := range txs {
+		tx := txSign.SignedTx
+		if tx.Nonce() != uint64(7+k) || tx.To().Hex() != want[k] || tx.ChainId().Int64() != 5 {
+			t.Fatalf("the %dth tx has nonce %d to %s on the chain %s", k, tx.Nonce(), tx.To().Hex(), tx.ChainId())
+		}
+		if tx.Type() != types.DynamicFeeTxType || tx.GasFeeCap().Int64() != 30 || tx.GasTipCap().Int64() != 2 || tx.Gas() != 22000 {
+			t.Fatalf("unexpected fee of the %dth tx %+v", k, tx)
+		}
+		from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(5)), tx)
+		if err != nil || from != common.HexToAddress(job.Account.Address) {
+			t.Fatalf("the %dth tx is signed by %s, %v", k, from.Hex(), err)
+		}
+	}
+
+	// an empty tip signs legacy txs
+	if txs, err = SignJob(job, big.NewInt(5), 0, "30", ""); err != nil || txs[0].SignedTx.Type() != types.LegacyTxType {
+		t.Fatalf("expect legacy txs, got %v", err)
+	}
+}
+
+func TestStaticGasFee(t *testing.T) {
+	fee := config.Fee{FeeMode: config.FeeModeDynamic, GasPrice: "30", MaxPriorityFeePerGas: "2"}
+	if gasPrice, tip, err := StaticGasFee(&fee); err != nil || gasPrice != "30" || tip != "2" {
+		t.Fatalf("dynamic fee %s %s %v", gasPrice, tip, err)
+	}
+	fee.FeeMode = config.FeeModeLegacy
+	if gasPrice, tip, err := StaticGasFee(&fee); err != nil || gasPrice != "30" || tip != "" {
+		t.Fatalf("legacy fee %s %s %v", gasPrice, tip, err)
+	}
+	fee.FeeMode = config.FeeModeAuto
+	if _, _, err := StaticGasFee(&fee); err == nil {
+		t.Fatal("the auto fee needs a node")
+	}
+}
+
+func TestBroadcast(t *testing.T) {
+	a, node := newTestApp(t)
+	_, key := newTestAccount(t)
+	var txs []*types.Transaction
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		txs = append(txs, coretest.SignTx(t, key, nonce, 1))
+	}
+
+	tracker := a.NewReceiptTracker(1, 1)
+	if failed := a.Broadcast(context.Background(), txs, 0, tracker); failed != 0 {
+		t.Fatalf("%d txs failed", failed)
+	}
+	sent := node.Sent()
+	if len(sent) != 3 || len(tracker.Receipts()) != 3 {
+		t.Fatalf("%d txs sent and %d tracked, want 3", len(sent), len(tracker.Receipts()))
+	}
+	for k, tx := range sent {
+		if tx.Hash() != txs[k].Hash() {
+			t.Fatalf("the %dth tx sent is %s, want %s", k, tx.Hash(), txs[k].Hash())
+		}
+	}
+
+	// the node refuses the txs
+	node.SetSendErr("insufficient funds for gas * price + value")
+	more := []*types.Transaction{coretest.SignTx(t, key, 3, 1), coretest.SignTx(t, key, 4, 1)}
+	if failed := a.Broadcast(context.Background(), more, 0, tracker); failed != 2 {
+		t.Fatalf("%d txs failed, want 2", failed)
+	}
+	node.SetSendErr("")
+
+	// nothing is sent once ctx is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if failed := a.Broadcast(ctx, more, 0, tracker); failed != 2 || len(node.Sent()) != 3 {
+		t.Fatalf("%d txs failed, %d sent", failed, len(node.Sent()))
+	}
+}
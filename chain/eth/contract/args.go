@@ -0,0 +1,123 @@
+package contract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"inscription/chain/util"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ConvertArg
+//
+//	@Description: value of the abi type from the command line argument: decimal or 0x integers, true/false,
+//	checksummed addresses, raw strings, 0x hex bytes, and json arrays for the slices and arrays
+//	@param t
+//	@param s
+//	@return interface{}
+//	@return error
+func ConvertArg(t abi.Type, s string) (interface{}, error) {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		return convertInt(t, s)
+	case abi.BoolTy:
+		return strconv.ParseBool(strings.TrimSpace(s))
+	case abi.AddressTy:
+		s = strings.TrimSpace(s)
+		if err := util.ValidateAddress(s); err != nil {
+			return nil, err
+		}
+		return common.HexToAddress(s), nil
+	case abi.StringTy:
+		return s, nil
+	case abi.BytesTy:
+		return util.HexDecodeString(strings.TrimSpace(s))
+	case abi.FixedBytesTy:
+		raw, err := util.HexDecodeString(strings.TrimSpace(s))
+		if err != nil {
+			return nil, err
+		}
+		if len(raw) != t.Size {
+			return nil, fmt.Errorf("%s needs %d bytes, got %d", t, t.Size, len(raw))
+		}
+		value := reflect.New(t.GetType()).Elem()
+		reflect.Copy(value, reflect.ValueOf(raw))
+		return value.Interface(), nil
+	case abi.SliceTy, abi.ArrayTy:
+		return convertList(t, s)
+	}
+	return nil, fmt.Errorf("type %s isn't supported", t)
+}
+
+func convertInt(t abi.Type, s string) (interface{}, error) {
+	s = strings.TrimSpace(s)
+	n, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid %s %q", t, s)
+	}
+	if t.T == abi.UintTy && n.Sign() < 0 {
+		return nil, fmt.Errorf("%s can't be negative", t)
+	}
+	bits := uint(t.Size)
+	if t.T == abi.IntTy {
+		bits--
+	}
+	limit := new(big.Int).Lsh(big.NewInt(1), bits)
+	if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+		return nil, fmt.Errorf("%s overflows %s", s, t)
+	}
+
+	// the abi packs the sized go kinds up to 64 bits, *big.Int above
+	value := reflect.New(t.GetType()).Elem()
+	switch value.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value.SetInt(n.Int64())
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value.SetUint(n.Uint64())
+	default:
+		return n, nil
+	}
+	return value.Interface(), nil
+}
+
+func convertList(t abi.Type, s string) (interface{}, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(s), &items); err != nil {
+		return nil, fmt.Errorf("%s needs a json array: %w", t, err)
+	}
+	if t.T == abi.ArrayTy && len(items) != t.Size {
+		return nil, fmt.Errorf("%s needs %d items, got %d", t, t.Size, len(items))
+	}
+
+	var value reflect.Value
+	if t.T == abi.ArrayTy {
+		value = reflect.New(t.GetType()).Elem()
+	} else {
+		value = reflect.MakeSlice(t.GetType(), len(items), len(items))
+	}
+	for i, item := range items {
+		// items are json strings or bare numbers and booleans
+		var text string
+		if err := json.Unmarshal(item, &text); err != nil {
+			text = string(item)
+		}
+		if t.Elem.T == abi.SliceTy || t.Elem.T == abi.ArrayTy {
+			text = string(item)
+		}
+		converted, err := ConvertArg(*t.Elem, text)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		elem := reflect.ValueOf(converted)
+		if !elem.Type().AssignableTo(value.Index(i).Type()) {
+			return nil, errors.New("unexpected item type " + elem.Type().String())
+		}
+		value.Index(i).Set(elem)
+	}
+	return value.Interface(), nil
+}
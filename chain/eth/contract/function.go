@@ -0,0 +1,182 @@
+package contract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"inscription/chain/util"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Function is a contract function to call, with the ABI it comes from to decode the custom errors
+type Function struct {
+	Method abi.Method
+	abi    *abi.ABI
+}
+
+// sizelessInt matches the int and uint types without size, which mean 256 bits
+var sizelessInt = regexp.MustCompile(`^(u?int)(\[|$)`)
+
+// ParseSignature
+//
+//	@Description: function of a human-readable signature, e.g. mint(uint256,address) or
+//	function mint(uint256 amount, address to) payable, tuples aren't supported
+//	@param signature
+//	@return *Function
+//	@return error
+func ParseSignature(signature string) (*Function, error) {
+	signature = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(signature), "function "))
+	open := strings.Index(signature, "(")
+	end := strings.Index(signature, ")")
+	if open <= 0 || end < open {
+		return nil, fmt.Errorf("invalid function signature %q", signature)
+	}
+	name := strings.TrimSpace(signature[:open])
+	params := signature[open+1 : end]
+	if strings.Contains(params, "(") {
+		return nil, errors.New("tuple arguments aren't supported, use the ABI json")
+	}
+
+	var inputs abi.Arguments
+	if strings.TrimSpace(params) != "" {
+		for i, param := range strings.Split(params, ",") {
+			fields := strings.Fields(param)
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("argument %d of %s has no type", i+1, name)
+			}
+			typ, err := abi.NewType(sizelessInt.ReplaceAllString(fields[0], "${1}256$2"), "", nil)
+			if err == nil {
+				err = checkSize(typ)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("argument %d of %s: %w", i+1, name, err)
+			}
+			argName := ""
+			if len(fields) > 1 {
+				argName = fields[len(fields)-1]
+			}
+			inputs = append(inputs, abi.Argument{Name: argName, Type: typ})
+		}
+	}
+
+	mutability := "nonpayable"
+	modifiers, _, _ := strings.Cut(signature[end+1:], "returns")
+	for _, modifier := range strings.Fields(modifiers) {
+		if modifier == "payable" || modifier == "view" || modifier == "pure" {
+			mutability = modifier
+		}
+	}
+	method := abi.NewMethod(name, name, abi.Function, mutability, mutability == "view" || mutability == "pure", mutability == "payable", inputs, nil)
+	return &Function{Method: method}, nil
+}
+
+// checkSize rejects the int sizes which aren't a multiple of 8 up to 256, the abi takes any of them
+func checkSize(t abi.Type) error {
+	for t.Elem != nil {
+		t = *t.Elem
+	}
+	if (t.T == abi.IntTy || t.T == abi.UintTy) && (t.Size%8 != 0 || t.Size < 8 || t.Size > 256) {
+		return fmt.Errorf("invalid type %s", t)
+	}
+	return nil
+}
+
+// LoadFunction
+//
+//	@Description: function of the ABI json file, a bare array or a truffle/hardhat artifact with an abi field
+//	@param path
+//	@param name function name, or its signature when it's overloaded
+//	@return *Function
+//	@return error
+func LoadFunction(path string, name string) (*Function, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseABI(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if method, ok := parsed.Methods[name]; ok {
+		return &Function{Method: method, abi: parsed}, nil
+	}
+	for _, method := range parsed.Methods {
+		if method.Sig == strings.ReplaceAll(name, " ", "") {
+			return &Function{Method: method, abi: parsed}, nil
+		}
+	}
+	return nil, fmt.Errorf("no function %s in %s", name, path)
+}
+
+func parseABI(content []byte) (*abi.ABI, error) {
+	trimmed := strings.TrimSpace(string(content))
+	if strings.HasPrefix(trimmed, "{") {
+		var artifact struct {
+			Abi json.RawMessage `json:"abi"`
+		}
+		if err := json.Unmarshal(content, &artifact); err != nil {
+			return nil, err
+		}
+		trimmed = string(artifact.Abi)
+	}
+	parsed, err := abi.JSON(strings.NewReader(trimmed))
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+// Encode
+//
+//	@Description: calldata of the call with the arguments, see ConvertArg for their format
+//	@receiver f
+//	@param args
+//	@return []byte
+//	@return error
+func (f *Function) Encode(args []string) ([]byte, error) {
+	if len(args) != len(f.Method.Inputs) {
+		return nil, fmt.Errorf("%s takes %d arguments, got %d", f.Method.Sig, len(f.Method.Inputs), len(args))
+	}
+	values := make([]interface{}, 0, len(args))
+	for i, arg := range args {
+		value, err := ConvertArg(f.Method.Inputs[i].Type, arg)
+		if err != nil {
+			return nil, fmt.Errorf("argument %d of %s: %w", i+1, f.Method.Sig, err)
+		}
+		values = append(values, value)
+	}
+	packed, err := f.Method.Inputs.Pack(values...)
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte{}, f.Method.ID...), packed...), nil
+}
+
+// DecodeRevert
+//
+//	@Description: reason of the revert data: a custom error of the ABI, Error(string) or Panic(uint256)
+//	@receiver f
+//	@param data
+//	@return string
+func (f *Function) DecodeRevert(data []byte) string {
+	if len(data) == 0 {
+		return "reverted without a reason"
+	}
+	if len(data) >= 4 && f.abi != nil {
+		var id [4]byte
+		copy(id[:], data)
+		if customErr, err := f.abi.ErrorByID(id); err == nil {
+			if values, err := customErr.Unpack(data); err == nil {
+				return fmt.Sprintf("%s%v", customErr.Name, values)
+			}
+		}
+	}
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+	return "reverted with " + util.HexEncodeToString(data)
+}
@@ -0,0 +1,100 @@
+package contract
+
+import (
+	"encoding/hex"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseSignature(t *testing.T) {
+	fn, err := ParseSignature("function transfer(address to, uint amount)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fn.Method.Sig != "transfer(address,uint256)" || hex.EncodeToString(fn.Method.ID) != "a9059cbb" || fn.Method.IsPayable() {
+		t.Fatalf("unexpected method %s %x", fn.Method.Sig, fn.Method.ID)
+	}
+	if fn, err = ParseSignature("mint(uint[] ids) payable"); err != nil || fn.Method.Sig != "mint(uint256[])" || !fn.Method.IsPayable() {
+		t.Fatalf("unexpected method %v %v", fn, err)
+	}
+	for _, signature := range []string{"mint", "mint(uint7)", "mint((uint256,address))"} {
+		if _, err = ParseSignature(signature); err == nil {
+			t.Fatalf("%s: expect an error", signature)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	fn, _ := ParseSignature("transfer(address,uint256)")
+	data, err := fn.Encode([]string{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "0x10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := "a9059cbb" +
+		"0000000000000000000000005aaeb6053f3e94c9b9a09f33669435e7ef1beaed" +
+		"0000000000000000000000000000000000000000000000000000000000000010"
+	if hex.EncodeToString(data) != expect {
+		t.Fatalf("unexpected calldata %x", data)
+	}
+
+	fn, _ = ParseSignature("mint(uint8 n, int16 d, bool b, bytes4 tag, string s, uint256[2] ids, address[] to)")
+	if _, err = fn.Encode([]string{"255", "-32768", "true", "0x01020304", " a b ", `[1,"2"]`, `["0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"]`}); err != nil {
+		t.Fatal(err)
+	}
+	invalid := [][]string{
+		{"256", "0", "true", "0x01020304", "", "[1,2]", "[]"},
+		{"1", "32768", "true", "0x01020304", "", "[1,2]", "[]"},
+		{"1", "0", "yes", "0x01020304", "", "[1,2]", "[]"},
+		{"1", "0", "true", "0x010203", "", "[1,2]", "[]"},
+		{"1", "0", "true", "0x01020304", "", "[1]", "[]"},
+		{"1", "0", "true", "0x01020304", "", "[1,2]", `["0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD"]`},
+		{"1"},
+	}
+	for _, args := range invalid {
+		if _, err = fn.Encode(args); err == nil {
+			t.Fatalf("%v: expect an error", args)
+		}
+	}
+}
+
+func TestDecodeRevert(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "abi.json")
+	artifact := `{"abi":[
+		{"type":"function","name":"mint","inputs":[{"name":"amount","type":"uint256"}],"outputs":[],"stateMutability":"payable"},
+		{"type":"error","name":"SoldOut","inputs":[{"name":"supply","type":"uint256"}]}
+	]}`
+	if err := os.WriteFile(path, []byte(artifact), 0600); err != nil {
+		t.Fatal(err)
+	}
+	fn, err := LoadFunction(path, "mint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fn.Method.IsPayable() {
+		t.Fatal("mint should be payable")
+	}
+
+	uint256, _ := abi.NewType("uint256", "", nil)
+	supply, _ := abi.Arguments{{Type: uint256}}.Pack(big.NewInt(100))
+	id := fn.abi.Errors["SoldOut"].ID
+	soldOut := append(append([]byte{}, id[:4]...), supply...)
+	if reason := fn.DecodeRevert(soldOut); reason != "SoldOut[100]" {
+		t.Fatalf("unexpected reason %s", reason)
+	}
+
+	str, _ := abi.NewType("string", "", nil)
+	message, _ := abi.Arguments{{Type: str}}.Pack("too many")
+	if reason := fn.DecodeRevert(append([]byte{0x08, 0xc3, 0x79, 0xa0}, message...)); reason != "too many" {
+		t.Fatalf("unexpected reason %s", reason)
+	}
+	if reason := fn.DecodeRevert([]byte{0xde, 0xad, 0xbe, 0xef}); !strings.HasSuffix(reason, "0xdeadbeef") {
+		t.Fatalf("unexpected reason %s", reason)
+	}
+	if _, err = LoadFunction(path, "burn"); err == nil {
+		t.Fatal("expect an error for a missing function")
+	}
+}
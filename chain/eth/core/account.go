@@ -1,25 +1,67 @@
 package core
 
 import (
+	"crypto/ecdsa"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	hdwallet "github.com/miguelmota/go-ethereum-hdwallet"
 	"github.com/tyler-smith/go-bip39"
 	"inscription/chain/util"
+	"strconv"
+	"strings"
 )
 
+// DerivationIndex is the placeholder of the account index in a derivation path template
+const DerivationIndex = "{index}"
+
 type Account struct {
 	Address    string `json:"address"`
 	PrivateKey string `json:"privateKey"`
 	PublicKey  string `json:"publicKey"`
 	Mnemonic   string `json:"mnemonic"`
+	signer     Signer
 }
 
 func NewAccount() *Account {
 	return &Account{}
 }
 
+// AccountWithSigner
+//
+//	@Description: account which signs through the signer, its private key stays empty
+//	@receiver a
+//	@param signer
+//	@return *Account
+func (a *Account) AccountWithSigner(signer Signer) *Account {
+	return &Account{
+		Address: signer.Address(),
+		signer:  signer,
+	}
+}
+
+// Signer
+//
+//	@Description: signer of the account, built from the private key when the account has none
+//	@receiver a
+//	@return Signer
+//	@return error
+func (a *Account) Signer() (Signer, error) {
+	if a.signer != nil {
+		return a.signer, nil
+	}
+	if a.PrivateKey == "" {
+		return nil, errors.New("the account has neither a signer nor a private key")
+	}
+	privateKeyECDSA, err := PrivateKeyToECDSA(a.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeySigner(privateKeyECDSA), nil
+}
+
 func (a *Account) AccountByMnemonic() (account *Account, err error) {
 	entropy, err := bip39.NewEntropy(128)
 	if err != nil {
@@ -37,7 +79,53 @@ func (a *Account) AccountInfoByMnemonic(mnemonic string) (account *Account, err
 	if err != nil {
 		return nil, err
 	}
-	acc, err := wallet.Derive(hdwallet.MustParseDerivationPath("m/44'/60'/0'/0/0"), false)
+	return deriveAccount(wallet, mnemonic, "m/44'/60'/0'/0/0")
+}
+
+// AccountsByMnemonic
+//
+//	@Description: derive count accounts from the mnemonic, the index placeholder of the path template goes from start
+//	@receiver a
+//	@param mnemonic
+//	@param passphrase optional BIP-39 passphrase
+//	@param pathTemplate derivation path with the placeholder {index}, e.g. m/44'/60'/0'/0/{index}
+//	@param start
+//	@param count
+//	@return []*Account
+//	@return error
+func (a *Account) AccountsByMnemonic(mnemonic, passphrase, pathTemplate string, start, count int) ([]*Account, error) {
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return nil, errors.New("invalid mnemonic")
+	}
+	if !strings.Contains(pathTemplate, DerivationIndex) {
+		return nil, fmt.Errorf("derivation path %s has no %s", pathTemplate, DerivationIndex)
+	}
+	if start < 0 || count <= 0 {
+		return nil, fmt.Errorf("invalid index range, start %d count %d", start, count)
+	}
+	wallet, err := hdwallet.NewFromSeed(bip39.NewSeed(mnemonic, passphrase))
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*Account, 0, count)
+	for i := start; i < start+count; i++ {
+		path := strings.ReplaceAll(pathTemplate, DerivationIndex, strconv.Itoa(i))
+		account, err := deriveAccount(wallet, mnemonic, path)
+		if err != nil {
+			return nil, fmt.Errorf("derive %s failed: %w", path, err)
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts, nil
+}
+
+func deriveAccount(wallet *hdwallet.Wallet, mnemonic string, path string) (account *Account, err error) {
+	derivationPath, err := hdwallet.ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	acc, err := wallet.Derive(derivationPath, false)
 	if err != nil {
 		return nil, err
 	}
@@ -84,3 +172,36 @@ func (a *Account) AccountWithPrivateKey(privateKey string) (account *Account, er
 	}
 	return
 }
+
+// AccountWithKey
+//
+//	@Description: account of a hex private key, or of the first address of a mnemonic when the key has several words
+//	@receiver a
+//	@param key
+//	@return account
+//	@return err
+func (a *Account) AccountWithKey(key string) (account *Account, err error) {
+	key = strings.TrimSpace(key)
+	if len(strings.Fields(key)) > 1 {
+		mnemonic := strings.Join(strings.Fields(key), " ")
+		if !bip39.IsMnemonicValid(mnemonic) {
+			return nil, errors.New("invalid mnemonic")
+		}
+		return a.AccountInfoByMnemonic(mnemonic)
+	}
+	return a.AccountWithPrivateKey(key)
+}
+
+// PrivateKeyToECDSA
+//
+//	@Description: parse the hex private key
+//	@param privateKey
+//	@return *ecdsa.PrivateKey
+//	@return error
+func PrivateKeyToECDSA(privateKey string) (*ecdsa.PrivateKey, error) {
+	priData, err := util.HexDecodeString(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.ToECDSA(priData)
+}
@@ -0,0 +1,21 @@
+package core
+
+import "testing"
+
+func TestAccountsByMnemonic(t *testing.T) {
+	mnemonic := "test test test test test test test test test test test junk"
+	accounts, err := NewAccount().AccountsByMnemonic(mnemonic, "", "m/44'/60'/0'/0/{index}", 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"}
+	for i, account := range accounts {
+		if account.Address != want[i] {
+			t.Fatalf("account %d is %s, want %s", i, account.Address, want[i])
+		}
+	}
+
+	if _, err := NewAccount().AccountsByMnemonic(mnemonic, "", "m/44'/60'/0'/0/0", 0, 2); err == nil {
+		t.Fatal("expected an error for the path without index")
+	}
+}
@@ -0,0 +1,429 @@
+// Package coretest is a fake json-rpc node for the tests of the packages built on core
+package coretest
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// ChainID is the chain id of the node
+const ChainID = 1337
+
+// Node answers the json-rpc requests of a chain: the sent txs wait in the mempool until Mine
+// puts the ones with the next nonce of their account in a new block
+type Node struct {
+	URL    string
+	Head   atomic.Uint64
+	Broken atomic.Bool // answers 503
+
+	delay     time.Duration
+	lock      sync.Mutex
+	calls     map[string]int
+	params    map[string][]json.RawMessage // of the last call of each method
+	signature string                       // X-Flashbots-Signature of the last request
+	body      []byte                       // of the last request
+
+	sendErr  string   // error of eth_sendRawTransaction
+	callErr  string   // eth_call reverts with it
+	gas      uint64   // eth_estimateGas, 0 reverts
+	gasPrice *big.Int // eth_gasPrice
+	baseFee  *big.Int // of the head, nil is a chain without EIP-1559
+	tip      *big.Int // answer of eth_maxPriorityFeePerGas, nil isn't supported
+	rewards  []*big.Int
+	balance  *big.Int // of the accounts without their own
+	balances map[common.Address]*big.Int
+	nonces   map[common.Address]uint64 // the mined nonce of each account
+	mempool  []*types.Transaction
+	sent     []*types.Transaction
+	txs      map[common.Hash]*types.Transaction
+	receipts map[common.Hash]*types.Receipt
+	reverts  map[common.Hash]bool
+	failSend map[uint64]string // error of the next send of a tx with the nonce
+}
+
+// NewNode
+//
+//	@Description: a node at the head served until the end of the test
+//	@param t
+//	@param head
+//	@param delay before every answer
+//	@return *Node
+func NewNode(t testing.TB, head uint64, delay time.Duration) *Node {
+	n := &Node{
+		delay:    delay,
+		calls:    make(map[string]int),
+		params:   make(map[string][]json.RawMessage),
+		gas:      21000,
+		gasPrice: big.NewInt(1),
+		balance:  big.NewInt(100),
+		balances: make(map[common.Address]*big.Int),
+		nonces:   make(map[common.Address]uint64),
+		failSend: make(map[uint64]string),
+		txs:      make(map[common.Hash]*types.Transaction),
+		receipts: make(map[common.Hash]*types.Receipt),
+		reverts:  make(map[common.Hash]bool),
+	}
+	n.Head.Store(head)
+	server := httptest.NewServer(n)
+	t.Cleanup(server.Close)
+	n.URL = server.URL
+	return n
+}
+
+// Count is the number of calls of the method
+func (n *Node) Count(method string) int {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.calls[method]
+}
+
+// LastCall is the params of the last call of the method, nil when it's never called
+func (n *Node) LastCall(method string) []json.RawMessage {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.params[method]
+}
+
+// LastRequest is the X-Flashbots-Signature header and the body of the last request
+func (n *Node) LastRequest() (string, []byte) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.signature, n.body
+}
+
+// SetSendErr makes eth_sendRawTransaction fail with the message, empty accepts the txs
+func (n *Node) SetSendErr(message string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.sendErr = message
+}
+
+// FailSend makes the next eth_sendRawTransaction of a tx with the nonce fail with the message
+func (n *Node) FailSend(nonce uint64, message string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.failSend[nonce] = message
+}
+
+// SetCall makes eth_call revert with the message when it isn't empty, and eth_estimateGas answer the gas, 0 reverts
+func (n *Node) SetCall(revert string, gas uint64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.callErr = revert
+	n.gas = gas
+}
+
+// SetGasPrice sets the answer of eth_gasPrice
+func (n *Node) SetGasPrice(gasPrice *big.Int) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.gasPrice = gasPrice
+}
+
+// SetFee sets the base fee of the head, the answer of eth_maxPriorityFeePerGas (nil isn't supported)
+// and the median reward of each block of eth_feeHistory
+func (n *Node) SetFee(baseFee, tip *big.Int, rewards ...*big.Int) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.baseFee, n.tip, n.rewards = baseFee, tip, rewards
+}
+
+// SetBalance sets the balance of the address, an empty address is every account without its own
+func (n *Node) SetBalance(address string, wei *big.Int) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if address == "" {
+		n.balance = wei
+		return
+	}
+	n.balances[common.HexToAddress(address)] = wei
+}
+
+// SetNonce sets the mined nonce of the address
+func (n *Node) SetNonce(address string, nonce uint64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.nonces[common.HexToAddress(address)] = nonce
+}
+
+// Revert makes the tx fail once it's mined
+func (n *Node) Revert(hash common.Hash) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.reverts[hash] = true
+}
+
+// Sent is every tx the node accepted in the order they arrived, mined or not
+func (n *Node) Sent() []*types.Transaction {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return append([]*types.Transaction(nil), n.sent...)
+}
+
+// Include adds the txs to the mempool as a builder got them, e.g. from a relay
+func (n *Node) Include(txs ...*types.Transaction) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	for _, tx := range txs {
+		n.txs[tx.Hash()] = tx
+		n.mempool = append(n.mempool, tx)
+	}
+}
+
+// Mine
+//
+//	@Description: a new head with the txs of the mempool which have the next nonce of their account,
+//	the ones whose nonce is taken are dropped
+//	@receiver n
+//	@return []*types.Transaction the mined txs
+func (n *Node) Mine() []*types.Transaction {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	block := n.Head.Load() + 1
+	signer := types.LatestSignerForChainID(big.NewInt(ChainID))
+	sort.SliceStable(n.mempool, func(i, j int) bool { return n.mempool[i].Nonce() < n.mempool[j].Nonce() })
+
+	var mined []*types.Transaction
+	left := n.mempool[:0]
+	for _, tx := range n.mempool {
+		from, _ := types.Sender(signer, tx)
+		switch nonce := n.nonces[from]; {
+		case tx.Nonce() < nonce:
+			continue
+		case tx.Nonce() > nonce:
+			left = append(left, tx)
+			continue
+		}
+		n.nonces[from]++
+		status := types.ReceiptStatusSuccessful
+		if n.reverts[tx.Hash()] {
+			status = types.ReceiptStatusFailed
+		}
+		n.receipts[tx.Hash()] = &types.Receipt{
+			Type:              tx.Type(),
+			Status:            status,
+			TxHash:            tx.Hash(),
+			GasUsed:           tx.Gas(),
+			CumulativeGasUsed: tx.Gas(),
+			BlockNumber:       new(big.Int).SetUint64(block),
+			Logs:              []*types.Log{},
+		}
+		mined = append(mined, tx)
+	}
+	n.mempool = left
+	n.Head.Store(block)
+	return mined
+}
+
+// SignTx
+//
+//	@Description: a legacy transfer of 1 wei from the key to itself, signed for the chain of the node
+//	@param t
+//	@param key
+//	@param nonce
+//	@param gasPrice a higher one replaces the tx with the same nonce
+//	@return *types.Transaction
+func SignTx(t testing.TB, key *ecdsa.PrivateKey, nonce uint64, gasPrice int64) *types.Transaction {
+	to := crypto.PubkeyToAddress(key.PublicKey)
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: nonce, To: &to, Value: big.NewInt(1), Gas: 21000, GasPrice: big.NewInt(gasPrice)}),
+		types.LatestSignerForChainID(big.NewInt(ChainID)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func (n *Node) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	body, _ := io.ReadAll(r.Body)
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	n.lock.Lock()
+	n.calls[req.Method]++
+	n.params[req.Method] = req.Params
+	n.signature = r.Header.Get("X-Flashbots-Signature")
+	n.body = body
+	n.lock.Unlock()
+	time.Sleep(n.delay)
+	if n.Broken.Load() {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	result, code, message := n.answer(req.Method, req.Params)
+	if message != "" {
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":%d,"message":%q,"data":"0x"}}`, req.ID, code, message)
+		return
+	}
+	answer, _ := json.Marshal(result)
+	fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%s}`, req.ID, answer)
+}
+
+// answer is the result of the method, or the code and the message of its error
+func (n *Node) answer(method string, params []json.RawMessage) (interface{}, int, string) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	head := n.Head.Load()
+	switch method {
+	case "eth_chainId":
+		return hexutil.Uint64(ChainID), 0, ""
+	case "eth_blockNumber":
+		return hexutil.Uint64(head), 0, ""
+	case "eth_getBlockByNumber":
+		number := head
+		var tag string
+		if len(params) > 0 && json.Unmarshal(params[0], &tag) == nil && strings.HasPrefix(tag, "0x") {
+			if number, _ = hexutil.DecodeUint64(tag); number > head {
+				return nil, 0, ""
+			}
+		}
+		return &types.Header{Number: new(big.Int).SetUint64(number), Difficulty: big.NewInt(0), BaseFee: n.baseFee}, 0, ""
+	case "eth_gasPrice":
+		return (*hexutil.Big)(n.gasPrice), 0, ""
+	case "eth_maxPriorityFeePerGas":
+		if n.tip == nil {
+			return nil, -32601, "the method eth_maxPriorityFeePerGas does not exist"
+		}
+		return (*hexutil.Big)(n.tip), 0, ""
+	case "eth_feeHistory":
+		rewards := make([][]*hexutil.Big, 0, len(n.rewards))
+		for _, reward := range n.rewards {
+			rewards = append(rewards, []*hexutil.Big{(*hexutil.Big)(reward)})
+		}
+		return map[string]interface{}{"oldestBlock": hexutil.Uint64(head), "reward": rewards}, 0, ""
+	case "eth_getBalance":
+		balance, ok := n.balances[address(params)]
+		if !ok {
+			balance = n.balance
+		}
+		return (*hexutil.Big)(balance), 0, ""
+	case "eth_getTransactionCount":
+		from := address(params)
+		nonce := n.nonces[from]
+		var tag string
+		if len(params) > 1 && json.Unmarshal(params[1], &tag) == nil && tag == "pending" {
+			nonce = n.pendingNonce(from)
+		}
+		return hexutil.Uint64(nonce), 0, ""
+	case "eth_call":
+		if n.callErr != "" {
+			return nil, 3, "execution reverted: " + n.callErr
+		}
+		return hexutil.Bytes{}, 0, ""
+	case "eth_estimateGas":
+		if n.gas == 0 {
+			return nil, 3, "execution reverted"
+		}
+		return hexutil.Uint64(n.gas), 0, ""
+	case "eth_sendRawTransaction":
+		return n.send(params)
+	case "eth_getTransactionReceipt":
+		if receipt, ok := n.receipts[hash(params)]; ok {
+			return receipt, 0, ""
+		}
+		return nil, 0, ""
+	case "eth_getTransactionByHash":
+		tx, ok := n.txs[hash(params)]
+		if !ok {
+			return nil, 0, ""
+		}
+		var fields map[string]interface{}
+		raw, _ := tx.MarshalJSON()
+		_ = json.Unmarshal(raw, &fields)
+		from, _ := types.Sender(types.LatestSignerForChainID(big.NewInt(ChainID)), tx)
+		fields["from"] = from
+		if receipt, ok := n.receipts[tx.Hash()]; ok {
+			fields["blockNumber"] = (*hexutil.Big)(receipt.BlockNumber)
+			fields["blockHash"] = common.Hash{1}
+		}
+		return fields, 0, ""
+	case "eth_sendPrivateTransaction":
+		return common.Hash{}, 0, ""
+	case "eth_sendBundle":
+		return map[string]string{"bundleHash": common.Hash{}.Hex()}, 0, ""
+	}
+	return nil, 0, ""
+}
+
+// send adds the raw tx of the params to the mempool
+func (n *Node) send(params []json.RawMessage) (interface{}, int, string) {
+	if n.sendErr != "" {
+		return nil, -32000, n.sendErr
+	}
+	var raw hexutil.Bytes
+	tx := new(types.Transaction)
+	if len(params) == 0 || json.Unmarshal(params[0], &raw) != nil || tx.UnmarshalBinary(raw) != nil {
+		return nil, -32602, "invalid raw transaction"
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(ChainID)), tx)
+	if err != nil {
+		return nil, -32000, "invalid sender"
+	}
+	if message, ok := n.failSend[tx.Nonce()]; ok {
+		delete(n.failSend, tx.Nonce())
+		return nil, -32000, message
+	}
+	if _, ok := n.txs[tx.Hash()]; ok {
+		return nil, -32000, "already known"
+	}
+	if tx.Nonce() < n.nonces[from] {
+		return nil, -32000, fmt.Sprintf("nonce too low: next nonce %d, tx nonce %d", n.nonces[from], tx.Nonce())
+	}
+	n.txs[tx.Hash()] = tx
+	n.sent = append(n.sent, tx)
+	n.mempool = append(n.mempool, tx)
+	return tx.Hash(), 0, ""
+}
+
+// pendingNonce is the mined nonce of the account followed by its txs in the mempool
+func (n *Node) pendingNonce(from common.Address) uint64 {
+	nonce := n.nonces[from]
+	signer := types.LatestSignerForChainID(big.NewInt(ChainID))
+	for found := true; found; {
+		found = false
+		for _, tx := range n.mempool {
+			if sender, _ := types.Sender(signer, tx); sender == from && tx.Nonce() == nonce {
+				nonce++
+				found = true
+			}
+		}
+	}
+	return nonce
+}
+
+func address(params []json.RawMessage) common.Address {
+	var a common.Address
+	if len(params) > 0 {
+		_ = json.Unmarshal(params[0], &a)
+	}
+	return a
+}
+
+func hash(params []json.RawMessage) common.Hash {
+	var h common.Hash
+	if len(params) > 0 {
+		_ = json.Unmarshal(params[0], &h)
+	}
+	return h
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+)
+
+// feeHistoryBlocks is the number of blocks averaged when eth_maxPriorityFeePerGas is not supported
+const feeHistoryBlocks = 5
+
+type DynamicFee struct {
+	BaseFee              *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+}
+
+// BaseFee
+//
+//	@Description: base fee of the latest block
+//	@receiver c
+//	@return *big.Int
+//	@return error
+func (c *Proxy) BaseFee() (*big.Int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.Timeout)*time.Second)
+	defer cancel()
+	header, err := c.RemoteRpcClient.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if header.BaseFee == nil {
+		return nil, errors.New("the chain doesn't support EIP-1559")
+	}
+	return header.BaseFee, nil
+}
+
+// SuggestGasTipCap
+//
+//	@Description: tip from eth_maxPriorityFeePerGas, falls back to the median reward of the recent blocks from eth_feeHistory
+//	@receiver c
+//	@return *big.Int
+//	@return error
+func (c *Proxy) SuggestGasTipCap() (*big.Int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.Timeout)*time.Second)
+	defer cancel()
+	tip, err := c.RemoteRpcClient.SuggestGasTipCap(ctx)
+	if err == nil {
+		return tip, nil
+	}
+
+	history, err := c.RemoteRpcClient.FeeHistory(ctx, feeHistoryBlocks, nil, []float64{50})
+	if err != nil {
+		return nil, err
+	}
+	sum, count := big.NewInt(0), int64(0)
+	for _, reward := range history.Reward {
+		if len(reward) > 0 {
+			sum.Add(sum, reward[0])
+			count++
+		}
+	}
+	if count == 0 {
+		return nil, errors.New("fee history has no reward")
+	}
+	return sum.Div(sum, big.NewInt(count)), nil
+}
+
+// SuggestDynamicFee
+//
+//	@Description: max fee = base fee * multiplier + tip, both bounded by the caps
+//	@receiver c
+//	@param multiplier
+//	@param maxFeeCap nil means no cap
+//	@param tipCap nil means no cap
+//	@return *DynamicFee
+//	@return error
+func (c *Proxy) SuggestDynamicFee(multiplier float64, maxFeeCap, tipCap *big.Int) (*DynamicFee, error) {
+	// big.Float panics on NaN
+	if math.IsNaN(multiplier) || math.IsInf(multiplier, 0) || multiplier < 0 {
+		return nil, fmt.Errorf("invalid fee multiplier %v", multiplier)
+	}
+	baseFee, err := c.BaseFee()
+	if err != nil {
+		return nil, err
+	}
+	tip, err := c.SuggestGasTipCap()
+	if err != nil {
+		return nil, err
+	}
+	if tipCap != nil && tip.Cmp(tipCap) > 0 {
+		tip = new(big.Int).Set(tipCap)
+	}
+
+	maxFee, _ := new(big.Float).Mul(new(big.Float).SetInt(baseFee), big.NewFloat(multiplier)).Int(nil)
+	if maxFee == nil {
+		return nil, fmt.Errorf("base fee %s * %v is not a fee", baseFee, multiplier)
+	}
+	maxFee.Add(maxFee, tip)
+	if maxFeeCap != nil && maxFee.Cmp(maxFeeCap) > 0 {
+		maxFee = new(big.Int).Set(maxFeeCap)
+	}
+	if maxFee.Cmp(tip) < 0 {
+		tip = new(big.Int).Set(maxFee)
+	}
+
+	return &DynamicFee{
+		BaseFee:              baseFee,
+		MaxFeePerGas:         maxFee,
+		MaxPriorityFeePerGas: tip,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"github.com/ethereum/go-ethereum/ethclient"
+	"inscription/chain/eth/core/coretest"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestSuggestDynamicFee(t *testing.T) {
+	tests := []struct {
+		name       string
+		baseFee    int64
+		tip        int64 // 0 isn't supported, the rewards are averaged
+		rewards    []*big.Int
+		multiplier float64
+		maxFeeCap  *big.Int
+		tipCap     *big.Int
+		maxFee     int64
+		wantTip    int64
+	}{
+		{name: "double base fee", baseFee: 100, tip: 10, multiplier: 2, maxFee: 210, wantTip: 10},
+		{name: "fractional multiplier", baseFee: 100, tip: 10, multiplier: 1.25, maxFee: 135, wantTip: 10},
+		{name: "tip cap", baseFee: 100, tip: 10, multiplier: 2, tipCap: big.NewInt(4), maxFee: 204, wantTip: 4},
+		{name: "max fee cap", baseFee: 100, tip: 10, multiplier: 2, maxFeeCap: big.NewInt(150), maxFee: 150, wantTip: 10},
+		{name: "max fee cap below the tip", baseFee: 100, tip: 10, multiplier: 2, maxFeeCap: big.NewInt(8), maxFee: 8, wantTip: 8},
+		{name: "fee history", baseFee: 100, rewards: []*big.Int{big.NewInt(4), big.NewInt(6), big.NewInt(11)}, multiplier: 2, maxFee: 207, wantTip: 7},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			node := coretest.NewNode(t, 100, 0)
+			var tip *big.Int
+			if test.tip > 0 {
+				tip = big.NewInt(test.tip)
+			}
+			node.SetFee(big.NewInt(test.baseFee), tip, test.rewards...)
+			proxy := dialTestProxy(t, node.URL)
+
+			fee, err := proxy.SuggestDynamicFee(test.multiplier, test.maxFeeCap, test.tipCap)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if fee.BaseFee.Int64() != test.baseFee || fee.MaxFeePerGas.Int64() != test.maxFee || fee.MaxPriorityFeePerGas.Int64() != test.wantTip {
+				t.Fatalf("got base fee %s, max fee %s, tip %s, want %d, %d, %d", fee.BaseFee, fee.MaxFeePerGas, fee.MaxPriorityFeePerGas, test.baseFee, test.maxFee, test.wantTip)
+			}
+		})
+	}
+
+	node := coretest.NewNode(t, 100, 0)
+	node.SetFee(nil, big.NewInt(10))
+	proxy := dialTestProxy(t, node.URL)
+	if _, err := proxy.SuggestDynamicFee(2, nil, nil); err == nil {
+		t.Fatal("expect an error without a base fee")
+	}
+	node.SetFee(big.NewInt(100), big.NewInt(10))
+	for _, multiplier := range []float64{math.NaN(), math.Inf(1), math.Inf(-1), -1} {
+		if _, err := proxy.SuggestDynamicFee(multiplier, nil, nil); err == nil {
+			t.Fatalf("expect an error for the multiplier %v", multiplier)
+		}
+	}
+	node.SetFee(big.NewInt(100), nil)
+	if _, err := proxy.SuggestDynamicFee(2, nil, nil); err == nil {
+		t.Fatal("expect an error without a tip or a reward")
+	}
+}
+
+// dialTestProxy is a proxy of the url without the chain id query
+func dialTestProxy(t *testing.T, url string) *Proxy {
+	client, err := ethclient.Dial(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	return &Proxy{RemoteRpcClient: client, Timeout: 1, rpcUrl: url}
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"sync"
+)
+
+// NonceManager hands out the nonces of each address locally, the node is only asked
+// for the first nonce and again after Reset
+type NonceManager struct {
+	fetch  func(address string) (uint64, error)
+	lock   sync.Mutex
+	nonces map[common.Address]uint64
+}
+
+// NewNonceManager
+//
+//	@Description:
+//	@param fetch read the pending nonce of the address from the node
+//	@return *NonceManager
+func NewNonceManager(fetch func(address string) (uint64, error)) *NonceManager {
+	return &NonceManager{
+		fetch:  fetch,
+		nonces: make(map[common.Address]uint64),
+	}
+}
+
+// Next
+//
+//	@Description: the nonce to use for the next tx of the address
+//	@receiver m
+//	@param address
+//	@return uint64
+//	@return error it never falls back to 0 when the node can't be read
+func (m *NonceManager) Next(address string) (uint64, error) {
+	key := common.HexToAddress(address)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	nonce, ok := m.nonces[key]
+	if !ok {
+		var err error
+		nonce, err = m.fetch(address)
+		if err != nil {
+			return 0, fmt.Errorf("fetch nonce of %s failed: %w", address, err)
+		}
+	}
+	m.nonces[key] = nonce + 1
+	return nonce, nil
+}
+
+// Release
+//
+//	@Description: give back a nonce which was not sent, the nonce is reused when it's the last one handed out, otherwise the address is resynced
+//	@receiver m
+//	@param address
+//	@param nonce
+func (m *NonceManager) Release(address string, nonce uint64) {
+	key := common.HexToAddress(address)
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if next, ok := m.nonces[key]; ok && next == nonce+1 {
+		m.nonces[key] = nonce
+		return
+	}
+	delete(m.nonces, key)
+}
+
+// Failed
+//
+//	@Description: the tx with the nonce was not sent. A tx which failed before its submission (building or signing it) releases the nonce,
+//	once submitted the node may have got it anyway (a timeout, a broken connection, a broadcast some nodes accepted) or
+//	rejected its nonce (nonce too low, replacement underpriced) so the address is resynced. The same as Reset, no other
+//	tx of the address may be in flight.
+//	@receiver m
+//	@param address
+//	@param nonce
+//	@param submitted whether the tx was handed to the node
+func (m *NonceManager) Failed(address string, nonce uint64, submitted bool) {
+	if submitted {
+		// the node knows better, read the nonce again for the next tx
+		m.Reset(address)
+		return
+	}
+	m.Release(address, nonce)
+}
+
+// Reset
+//
+//	@Description: forget the local nonce, the next call of Next reads it from the node again. The pending nonce of the node
+//	doesn't count the txs of the address still in flight, so Next would hand their nonces out twice: call it once they're
+//	sent or failed, e.g. Mint waits until no tx after a failed one is left.
+//	@receiver m
+//	@param address
+func (m *NonceManager) Reset(address string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.nonces, common.HexToAddress(address))
+}
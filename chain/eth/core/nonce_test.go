@@ -0,0 +1,69 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+const nonceTestAddress = "0x0b39fb6bce3381115db85210666585ebb9d32e25"
+
+func TestNonceManager(t *testing.T) {
+	fetched := 0
+	m := NewNonceManager(func(address string) (uint64, error) {
+		fetched++
+		return 7, nil
+	})
+
+	for want := uint64(7); want < 10; want++ {
+		nonce, err := m.Next(nonceTestAddress)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if nonce != want {
+			t.Fatalf("nonce %d, want %d", nonce, want)
+		}
+	}
+	if fetched != 1 {
+		t.Fatalf("fetched %d times, want 1", fetched)
+	}
+
+	m.Release(nonceTestAddress, 9)
+	if nonce, _ := m.Next(nonceTestAddress); nonce != 9 {
+		t.Fatalf("released nonce %d, want 9", nonce)
+	}
+
+	m.Reset(nonceTestAddress)
+	if nonce, _ := m.Next(nonceTestAddress); nonce != 7 || fetched != 2 {
+		t.Fatalf("nonce %d after reset fetched %d times, want 7 and 2", nonce, fetched)
+	}
+}
+
+func TestNonceManagerFailed(t *testing.T) {
+	fetched := 0
+	m := NewNonceManager(func(address string) (uint64, error) {
+		fetched++
+		return 7, nil
+	})
+	nonce, _ := m.Next(nonceTestAddress)
+
+	// it failed to be signed, the nonce is used by the next tx
+	m.Failed(nonceTestAddress, nonce, false)
+	if next, _ := m.Next(nonceTestAddress); next != 7 || fetched != 1 {
+		t.Fatalf("nonce %d fetched %d times, want 7 and 1", next, fetched)
+	}
+
+	// a timeout of the send, the node may have got the tx
+	m.Failed(nonceTestAddress, 7, true)
+	if next, _ := m.Next(nonceTestAddress); next != 7 || fetched != 2 {
+		t.Fatalf("nonce %d fetched %d times, want 7 and 2", next, fetched)
+	}
+}
+
+func TestNonceManagerFetchError(t *testing.T) {
+	m := NewNonceManager(func(address string) (uint64, error) {
+		return 0, errors.New("connection refused")
+	})
+	if _, err := m.Next(nonceTestAddress); err == nil {
+		t.Fatal("expected the fetch error instead of nonce 0")
+	}
+}
@@ -0,0 +1,491 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Backend is the node api the proxy calls, a single node or a pool of them
+type Backend interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	Close()
+}
+
+const (
+	// PoolMaxLag is the most blocks an endpoint may be behind the highest one to take the reads
+	PoolMaxLag = 2
+	// PoolCheckInterval is the time between two health checks of the endpoints
+	PoolCheckInterval = 10 * time.Second
+	// poolMaxFailures consecutive transport errors put an endpoint down until a health check succeeds
+	poolMaxFailures = 3
+	// poolDecay is the weight of the history in the moving averages of the latency and the error rate
+	poolDecay = 0.8
+)
+
+// endpoint is one node of the pool and its health
+type endpoint struct {
+	url       string
+	client    *ethclient.Client
+	transport *limitedTransport
+
+	lock      sync.Mutex
+	latency   time.Duration // moving average of the successful requests
+	errorRate float64       // moving average of the transport errors, from 0 to 1
+	failures  int           // consecutive transport errors
+	head      uint64
+	down      bool
+	lastErr   error
+
+	chainChecked bool // the chain id was checked, the endpoints down at the start are checked once up
+	wrongChain   bool // the endpoint serves another chain and is never used
+}
+
+// EndpointStatus is the health of an endpoint of the pool
+type EndpointStatus struct {
+	Url       string
+	Head      uint64
+	Lag       uint64
+	Latency   time.Duration
+	ErrorRate float64
+	Down      bool
+	LastErr   error
+}
+
+// Pool is a Backend over several nodes of the same chain: the reads go to the healthiest endpoint and fail over
+// to the next ones on transport errors, the txs go to one endpoint the same way or to all of them with broadcast.
+type Pool struct {
+	endpoints []*endpoint
+	chainId   *big.Int
+	timeout   time.Duration // of each attempt
+	broadcast atomic.Bool
+	head      atomic.Uint64
+	stop      chan struct{}
+	closeOnce sync.Once
+}
+
+// DialPool
+//
+//	@Description: connect to every url and check they serve the same chain, an unreachable url starts down
+//	@param ctx
+//	@param urls
+//	@param timeout of each request to an endpoint
+//	@return *Pool
+//	@return error when no url is reachable or they serve different chains
+func DialPool(ctx context.Context, urls []string, timeout time.Duration) (*Pool, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("rpc url can't be empty")
+	}
+	p := &Pool{timeout: timeout, stop: make(chan struct{})}
+	for _, url := range urls {
+		transport := &limitedTransport{base: http.DefaultTransport}
+		rpcClient, err := rpc.DialOptions(ctx, url, rpc.WithHTTPClient(&http.Client{Transport: transport}))
+		if err != nil {
+			p.Close()
+			return nil, fmt.Errorf("%s: %w", url, err)
+		}
+		p.endpoints = append(p.endpoints, &endpoint{url: url, client: ethclient.NewClient(rpcClient), transport: transport})
+	}
+
+	for _, e := range p.endpoints {
+		attempt, cancel := context.WithTimeout(ctx, timeout)
+		chainId, err := e.client.ChainID(attempt)
+		cancel()
+		if err != nil {
+			e.fail(err)
+			e.down = true
+			continue
+		}
+		e.chainChecked = true
+		if p.chainId == nil {
+			p.chainId = chainId
+		} else if p.chainId.Cmp(chainId) != 0 {
+			p.Close()
+			return nil, fmt.Errorf("%s serves the chain %s, not %s", e.url, chainId, p.chainId)
+		}
+	}
+	if p.chainId == nil {
+		p.Close()
+		return nil, fmt.Errorf("no rpc url is reachable: %w", p.endpoints[0].lastErr)
+	}
+
+	p.check()
+	go p.loop()
+	return p, nil
+}
+
+// SetBroadcast
+//
+//	@Description: send every tx to all the endpoints at once instead of one
+//	@receiver p
+//	@param broadcast
+func (p *Pool) SetBroadcast(broadcast bool) {
+	p.broadcast.Store(broadcast)
+}
+
+// Status
+//
+//	@Description: the health of every endpoint, in the order of the urls
+//	@receiver p
+//	@return []EndpointStatus
+func (p *Pool) Status() []EndpointStatus {
+	head := p.head.Load()
+	statuses := make([]EndpointStatus, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		e.lock.Lock()
+		status := EndpointStatus{Url: e.url, Head: e.head, Latency: e.latency, ErrorRate: e.errorRate, Down: e.down, LastErr: e.lastErr}
+		e.lock.Unlock()
+		if head > status.Head {
+			status.Lag = head - status.Head
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func (p *Pool) Close() {
+	p.closeOnce.Do(func() {
+		close(p.stop)
+		for _, e := range p.endpoints {
+			e.client.Close()
+		}
+	})
+}
+
+// setRateLimit limits the requests sent to each endpoint
+func (p *Pool) setRateLimit(rps float64) {
+	for _, e := range p.endpoints {
+		if rps <= 0 {
+			e.transport.limiter.Store(nil)
+			continue
+		}
+		e.transport.limiter.Store(NewRateLimiter(rps))
+	}
+}
+
+// loop checks the health of the endpoints until the pool is closed
+func (p *Pool) loop() {
+	ticker := time.NewTicker(PoolCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.check()
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// check queries the head of every endpoint at the same time, an endpoint answering is up again
+func (p *Pool) check() {
+	var wg sync.WaitGroup
+	for _, e := range p.endpoints {
+		wg.Add(1)
+		go func(e *endpoint) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+			defer cancel()
+			start := time.Now()
+			head, err := e.client.BlockNumber(ctx)
+			if err != nil {
+				e.fail(err)
+				return
+			}
+			e.lock.Lock()
+			checked := e.chainChecked
+			e.lock.Unlock()
+			if !checked {
+				chainId, err := e.client.ChainID(ctx)
+				if err != nil {
+					e.fail(err)
+					return
+				}
+				e.lock.Lock()
+				e.chainChecked = true
+				e.wrongChain = chainId.Cmp(p.chainId) != 0
+				e.lock.Unlock()
+			}
+			e.succeed(time.Since(start))
+			e.lock.Lock()
+			e.head = head
+			e.down = e.wrongChain
+			e.lock.Unlock()
+			for {
+				highest := p.head.Load()
+				if head <= highest || p.head.CompareAndSwap(highest, head) {
+					break
+				}
+			}
+		}(e)
+	}
+	wg.Wait()
+}
+
+func (e *endpoint) succeed(latency time.Duration) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.latency == 0 {
+		e.latency = latency
+	} else {
+		e.latency = time.Duration(poolDecay*float64(e.latency) + (1-poolDecay)*float64(latency))
+	}
+	e.errorRate *= poolDecay
+	e.failures = 0
+	e.down = e.wrongChain
+}
+
+func (e *endpoint) fail(err error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.errorRate = poolDecay*e.errorRate + (1 - poolDecay)
+	e.lastErr = err
+	e.failures++
+	if e.failures >= poolMaxFailures {
+		e.down = true
+	}
+}
+
+// ranked is the endpoints from the healthiest: the ones up and close to the head by latency weighted by errors,
+// then the lagging ones, then the ones down
+func (p *Pool) ranked() []*endpoint {
+	head := p.head.Load()
+	type ranking struct {
+		e     *endpoint
+		tier  int
+		score float64
+	}
+	rankings := make([]ranking, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		e.lock.Lock()
+		r := ranking{e: e, score: float64(e.latency) * (1 + 10*e.errorRate)}
+		switch {
+		case e.wrongChain:
+			e.lock.Unlock()
+			continue
+		case e.down:
+			r.tier = 2
+		case head > e.head+PoolMaxLag:
+			r.tier = 1
+		}
+		e.lock.Unlock()
+		rankings = append(rankings, r)
+	}
+	sort.SliceStable(rankings, func(i, j int) bool {
+		if rankings[i].tier != rankings[j].tier {
+			return rankings[i].tier < rankings[j].tier
+		}
+		return rankings[i].score < rankings[j].score
+	})
+	endpoints := make([]*endpoint, 0, len(rankings))
+	for _, r := range rankings {
+		endpoints = append(endpoints, r.e)
+	}
+	return endpoints
+}
+
+// Retryable
+//
+//	@Description: whether the error is the transport's and the request may succeed on another endpoint,
+//	an answer of the node such as a revert or a missing receipt isn't
+//	@param err
+//	@return bool
+func Retryable(err error) bool {
+	if err == nil || errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= http.StatusInternalServerError
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		// -32005 is the limit exceeded of EIP-1474
+		return rpcErr.ErrorCode() == -32005
+	}
+	return true
+}
+
+// poolCall runs the request on the endpoints from the healthiest until one answers
+func poolCall[T any](p *Pool, ctx context.Context, request func(ctx context.Context, client *ethclient.Client) (T, error)) (result T, err error) {
+	endpoints := p.ranked()
+	if len(endpoints) == 0 {
+		return result, errors.New("no endpoint left in the rpc pool")
+	}
+	for _, e := range endpoints {
+		if ctx.Err() != nil {
+			break
+		}
+		attempt, cancel := context.WithTimeout(ctx, p.timeout)
+		start := time.Now()
+		result, err = request(attempt, e.client)
+		cancel()
+		if !Retryable(err) {
+			e.succeed(time.Since(start))
+			return result, err
+		}
+		e.fail(err)
+		err = fmt.Errorf("%s: %w", e.url, err)
+	}
+	return result, err
+}
+
+func (p *Pool) ChainID(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(p.chainId), nil
+}
+
+func (p *Pool) BlockNumber(ctx context.Context) (uint64, error) {
+	return poolCall(p, ctx, func(ctx context.Context, client *ethclient.Client) (uint64, error) {
+		return client.BlockNumber(ctx)
+	})
+}
+
+func (p *Pool) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return poolCall(p, ctx, func(ctx context.Context, client *ethclient.Client) (*types.Header, error) {
+		return client.HeaderByNumber(ctx, number)
+	})
+}
+
+func (p *Pool) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return poolCall(p, ctx, func(ctx context.Context, client *ethclient.Client) (*big.Int, error) {
+		return client.BalanceAt(ctx, account, blockNumber)
+	})
+}
+
+func (p *Pool) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return poolCall(p, ctx, func(ctx context.Context, client *ethclient.Client) (uint64, error) {
+		return client.NonceAt(ctx, account, blockNumber)
+	})
+}
+
+func (p *Pool) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return poolCall(p, ctx, func(ctx context.Context, client *ethclient.Client) (uint64, error) {
+		return client.PendingNonceAt(ctx, account)
+	})
+}
+
+func (p *Pool) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	type found struct {
+		tx        *types.Transaction
+		isPending bool
+	}
+	result, err := poolCall(p, ctx, func(ctx context.Context, client *ethclient.Client) (found, error) {
+		tx, isPending, err := client.TransactionByHash(ctx, hash)
+		return found{tx, isPending}, err
+	})
+	return result.tx, result.isPending, err
+}
+
+func (p *Pool) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return poolCall(p, ctx, func(ctx context.Context, client *ethclient.Client) (*types.Receipt, error) {
+		return client.TransactionReceipt(ctx, txHash)
+	})
+}
+
+func (p *Pool) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return poolCall(p, ctx, func(ctx context.Context, client *ethclient.Client) (uint64, error) {
+		return client.EstimateGas(ctx, msg)
+	})
+}
+
+func (p *Pool) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) ([]byte, error) {
+	return poolCall(p, ctx, func(ctx context.Context, client *ethclient.Client) ([]byte, error) {
+		return client.PendingCallContract(ctx, msg)
+	})
+}
+
+func (p *Pool) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return poolCall(p, ctx, func(ctx context.Context, client *ethclient.Client) (*big.Int, error) {
+		return client.SuggestGasTipCap(ctx)
+	})
+}
+
+func (p *Pool) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return poolCall(p, ctx, func(ctx context.Context, client *ethclient.Client) (*ethereum.FeeHistory, error) {
+		return client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+}
+
+// SendTransaction
+//
+//	@Description: send the tx to the healthiest endpoint with failover, or to every endpoint at once with broadcast.
+//	A broadcast succeeds when any endpoint accepts the tx.
+//	@receiver p
+//	@param ctx
+//	@param tx
+//	@return error
+func (p *Pool) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if !p.broadcast.Load() {
+		_, err := poolCall(p, ctx, func(ctx context.Context, client *ethclient.Client) (struct{}, error) {
+			return struct{}{}, client.SendTransaction(ctx, tx)
+		})
+		if err != nil && strings.Contains(err.Error(), "already known") {
+			// an endpoint which timed out got the tx anyway
+			return nil
+		}
+		return err
+	}
+
+	endpoints := p.ranked()
+	errs := make([]error, len(endpoints))
+	var wg sync.WaitGroup
+	for i, e := range endpoints {
+		wg.Add(1)
+		go func(i int, e *endpoint) {
+			defer wg.Done()
+			attempt, cancel := context.WithTimeout(ctx, p.timeout)
+			defer cancel()
+			start := time.Now()
+			err := e.client.SendTransaction(attempt, tx)
+			if Retryable(err) {
+				e.fail(err)
+				errs[i] = fmt.Errorf("%s: %w", e.url, err)
+				return
+			}
+			e.succeed(time.Since(start))
+			if err != nil && !strings.Contains(err.Error(), "already known") {
+				errs[i] = fmt.Errorf("%s: %w", e.url, err)
+			}
+		}(i, e)
+	}
+	wg.Wait()
+
+	// an answer of a node tells more than a transport error
+	var first error
+	for _, err := range errs {
+		if err == nil {
+			return nil
+		}
+		if first == nil || (Retryable(first) && !Retryable(err)) {
+			first = err
+		}
+	}
+	if first == nil {
+		return errors.New("no endpoint left in the rpc pool")
+	}
+	return first
+}
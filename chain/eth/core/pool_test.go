@@ -0,0 +1,126 @@
+package core
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"inscription/chain/eth/core/coretest"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func dialTestPool(t *testing.T, urls ...string) *Pool {
+	pool, err := DialPool(context.Background(), urls, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func TestPoolFailover(t *testing.T) {
+	fast := coretest.NewNode(t, 100, 0)
+	slow := coretest.NewNode(t, 100, 20*time.Millisecond)
+	pool := dialTestPool(t, slow.URL, fast.URL)
+
+	if _, err := pool.BalanceAt(context.Background(), common.Address{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if fast.Count("eth_getBalance") != 1 || slow.Count("eth_getBalance") != 0 {
+		t.Fatal("the read should go to the fastest endpoint")
+	}
+
+	fast.Broken.Store(true)
+	for i := 0; i < poolMaxFailures; i++ {
+		if _, err := pool.BalanceAt(context.Background(), common.Address{}, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if slow.Count("eth_getBalance") != poolMaxFailures {
+		t.Fatalf("the slow endpoint got %d reads, want %d", slow.Count("eth_getBalance"), poolMaxFailures)
+	}
+	if status := pool.Status(); !status[1].Down || status[0].Down {
+		t.Fatalf("only the broken endpoint should be down: %+v", status)
+	}
+	if _, err := pool.BalanceAt(context.Background(), common.Address{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if fast.Count("eth_getBalance") != poolMaxFailures+1 {
+		t.Fatal("the endpoint down should be tried last")
+	}
+
+	fast.Broken.Store(false)
+	pool.check()
+	if pool.Status()[1].Down {
+		t.Fatal("the endpoint should be up again after a health check")
+	}
+}
+
+func TestPoolLag(t *testing.T) {
+	lagging := coretest.NewNode(t, 90, 0)
+	synced := coretest.NewNode(t, 100, 20*time.Millisecond)
+	pool := dialTestPool(t, lagging.URL, synced.URL)
+
+	if _, err := pool.BalanceAt(context.Background(), common.Address{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if synced.Count("eth_getBalance") != 1 || lagging.Count("eth_getBalance") != 0 {
+		t.Fatal("the read should skip the endpoint behind the head")
+	}
+	if status := pool.Status(); status[0].Lag != 10 {
+		t.Fatalf("lag %d, want 10", status[0].Lag)
+	}
+}
+
+func TestPoolNodeError(t *testing.T) {
+	first := coretest.NewNode(t, 100, 0)
+	second := coretest.NewNode(t, 100, 20*time.Millisecond)
+	first.SetCall("", 0)
+	second.SetCall("", 0)
+	pool := dialTestPool(t, first.URL, second.URL)
+
+	// a revert is the answer of the node, not a transport error
+	_, err := pool.EstimateGas(context.Background(), NewCallMsg().Msg)
+	if err == nil || Retryable(err) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if first.Count("eth_estimateGas") != 1 || second.Count("eth_estimateGas") != 0 {
+		t.Fatal("the answer of a node shouldn't fail over")
+	}
+}
+
+func TestPoolBroadcast(t *testing.T) {
+	first := coretest.NewNode(t, 100, 0)
+	second := coretest.NewNode(t, 100, 0)
+	second.SetSendErr("already known")
+	pool := dialTestPool(t, first.URL, second.URL)
+
+	key, _ := crypto.GenerateKey()
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Gas: 21000, GasPrice: big.NewInt(1)}), types.LatestSignerForChainID(big.NewInt(1337)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = pool.SendTransaction(context.Background(), tx); err != nil {
+		t.Fatal(err)
+	}
+	if first.Count("eth_sendRawTransaction")+second.Count("eth_sendRawTransaction") != 1 {
+		t.Fatal("the tx should go to one endpoint without broadcast")
+	}
+
+	pool.SetBroadcast(true)
+	if err = pool.SendTransaction(context.Background(), tx); err != nil {
+		t.Fatal(err)
+	}
+	if first.Count("eth_sendRawTransaction")+second.Count("eth_sendRawTransaction") != 3 {
+		t.Fatal("the tx should go to every endpoint with broadcast")
+	}
+
+	first.SetSendErr("nonce too low")
+	second.SetSendErr("nonce too low")
+	if err = pool.SendTransaction(context.Background(), tx); err == nil {
+		t.Fatal("expect an error when no endpoint accepts the tx")
+	}
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateLimiter spaces the requests evenly so that at most rps of them pass each second
+type RateLimiter struct {
+	lock     sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// NewRateLimiter
+//
+//	@Description:
+//	@param rps requests per second, must be positive
+//	@return *RateLimiter
+func NewRateLimiter(rps float64) *RateLimiter {
+	return &RateLimiter{
+		interval: time.Duration(float64(time.Second) / rps),
+	}
+}
+
+// Wait
+//
+//	@Description: block until the next request is allowed
+//	@receiver l
+//	@param ctx
+//	@return error the error of ctx when it's done first
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.lock.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.lock.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// limitedTransport applies the rate limiter of the proxy to every http request sent to the node
+type limitedTransport struct {
+	base    http.RoundTripper
+	limiter atomic.Pointer[RateLimiter]
+}
+
+func (t *limitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if limiter := t.limiter.Load(); limiter != nil {
+		if err := limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+	return t.base.RoundTrip(req)
+}
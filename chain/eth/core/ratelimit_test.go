@@ -0,0 +1,41 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := NewRateLimiter(100)
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the first request passes at once, the next ones 10ms apart
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond || elapsed > time.Second {
+		t.Fatalf("6 requests at 100 rps took %s", elapsed)
+	}
+
+	// the requests are spaced from the last one, not the burst before. At 10 rps a delayed request
+	// would wait 100ms, half of it leaves room for a loaded machine
+	limiter = NewRateLimiter(10)
+	for i := 0; i < 3; i++ {
+		limiter.Wait(context.Background())
+	}
+	time.Sleep(300 * time.Millisecond)
+	start = time.Now()
+	if err := limiter.Wait(context.Background()); err != nil || time.Since(start) > 50*time.Millisecond {
+		t.Fatalf("an idle limiter should let the request pass at once, waited %s, %v", time.Since(start), err)
+	}
+
+	limiter = NewRateLimiter(1)
+	limiter.Wait(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expect the error of ctx, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// SignOffline
+//
+//	@Description: sign the tx for the chain id without a node, the nonce is required
+//	@param signer
+//	@param transaction
+//	@param chainId
+//	@return *BuildTxResult
+//	@return error
+func SignOffline(signer Signer, transaction *Transaction, chainId *big.Int) (*BuildTxResult, error) {
+	if signer == nil || transaction == nil || chainId == nil {
+		return nil, errors.New("param is empty")
+	}
+	if transaction.Nonce == "" {
+		return nil, errors.New("the nonce is required to sign offline")
+	}
+	txNoSign, err := transaction.GetRawTx()
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := signer.SignTx(txNoSign, chainId)
+	if err != nil {
+		return nil, err
+	}
+	return &BuildTxResult{
+		SignedTx: signedTx,
+		TxHex:    signedTx.Hash().String(),
+		From:     signer.Address(),
+	}, nil
+}
+
+// ChainID
+//
+//	@Description: the chain id of the node
+//	@receiver c
+//	@return *big.Int
+func (c *Proxy) ChainID() *big.Int {
+	return c.chainId
+}
+
+// WriteRawTxs
+//
+//	@Description: write the signed txs to the file, the hex of the RLP encoding of one tx per line
+//	@param path
+//	@param txs
+//	@return error
+func WriteRawTxs(path string, txs []*types.Transaction) error {
+	var buf bytes.Buffer
+	for _, tx := range txs {
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		buf.WriteString(hexutil.Encode(raw))
+		buf.WriteByte('\n')
+	}
+	return os.WriteFile(path, buf.Bytes(), 0600)
+}
+
+// ReadRawTxs
+//
+//	@Description: read the signed txs of a file written by WriteRawTxs, the empty lines and the ones starting with # are skipped
+//	@param path
+//	@return []*types.Transaction
+//	@return error
+func ReadRawTxs(path string) ([]*types.Transaction, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var txs []*types.Transaction
+	scanner := bufio.NewScanner(file)
+	// a tx carries up to 128KB of data
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		raw, err := hexutil.Decode(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		tx := new(types.Transaction)
+		if err = tx.UnmarshalBinary(raw); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		txs = append(txs, tx)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(txs) == 0 {
+		return nil, fmt.Errorf("no signed tx in %s", path)
+	}
+	return txs, nil
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRawTxs(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer := NewKeySigner(key)
+	chainId := big.NewInt(1337)
+
+	if _, err := SignOffline(signer, NewTransaction("", "1", "21000", "", signer.Address(), "0", ""), chainId); err == nil {
+		t.Fatal("expect an error without nonce")
+	}
+	legacy, err := SignOffline(signer, NewTransaction("7", "1000", "21000", "", signer.Address(), "0", "0x6869"), chainId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dynamic, err := SignOffline(signer, NewTransaction("8", "1000", "21000", "10", signer.Address(), "1", ""), chainId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "signed.txt")
+	if err = WriteRawTxs(path, []*types.Transaction{legacy.SignedTx, dynamic.SignedTx}); err != nil {
+		t.Fatal(err)
+	}
+	txs, err := ReadRawTxs(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 2 || txs[0].Hash() != legacy.SignedTx.Hash() || txs[1].Hash() != dynamic.SignedTx.Hash() {
+		t.Fatal("the txs read differ from the ones written")
+	}
+	if txs[1].Type() != types.DynamicFeeTxType || txs[1].Nonce() != 8 || txs[1].ChainId().Cmp(chainId) != 0 {
+		t.Fatalf("unexpected tx %+v", txs[1])
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(chainId), txs[0])
+	if err != nil || from.Hex() != signer.Address() {
+		t.Fatalf("sender %s, want %s", from.Hex(), signer.Address())
+	}
+
+	if err = os.WriteFile(path, []byte("# comment\n0x1234\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ReadRawTxs(path); err == nil {
+		t.Fatal("expect an error for an invalid tx")
+	}
+}
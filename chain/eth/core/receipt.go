@@ -0,0 +1,350 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// receiptPollInterval is the interval between two receipt queries of the tracker
+const receiptPollInterval = 3 * time.Second
+
+type TxStatus string
+
+const (
+	TxPending   TxStatus = "pending"   // not mined or not enough confirmations yet
+	TxConfirmed TxStatus = "confirmed" // mined with status success
+	TxReverted  TxStatus = "reverted"  // mined with status failed
+	TxDropped   TxStatus = "dropped"   // never mined, the nonce is used by another tx or the relay gave up on the tx
+)
+
+type TxReceipt struct {
+	Hash        string
+	From        string
+	Nonce       uint64
+	Status      TxStatus
+	BlockNumber uint64
+	GasUsed     uint64
+	Replaced    []string // hashes of the earlier txs with the same nonce, any of them may be mined
+	sentAt      time.Time
+	seen        atomic.Bool
+}
+
+// Hashes
+//
+//	@Description: the current hash followed by the replaced ones
+//	@receiver r
+//	@return []string
+func (r *TxReceipt) Hashes() []string {
+	return append([]string{r.Hash}, r.Replaced...)
+}
+
+// Seen
+//
+//	@Description: whether the node announced the tx in its mempool, only known with a websocket
+//	@receiver r
+//	@return bool
+func (r *TxReceipt) Seen() bool {
+	return r.seen.Load()
+}
+
+// Settled
+//
+//	@Description: whether the status won't change anymore
+//	@receiver r
+//	@return bool
+func (r *TxReceipt) Settled() bool {
+	return r.Status != TxPending
+}
+
+// ReceiptTracker polls the receipts of the sent txs until they have enough confirmations,
+// at every new head too when the proxy follows the chain through a websocket
+type ReceiptTracker struct {
+	proxy         *Proxy
+	confirmations uint64
+	timeout       time.Duration
+	stuckAfter    time.Duration
+	interval      time.Duration // between two polls
+	replace       func(receipt *TxReceipt) (*BuildTxResult, error)
+	lock          sync.Mutex
+	receipts      []*TxReceipt
+	hashes        map[string]*TxReceipt // the receipt of every hash sent, the replaced ones included
+}
+
+// NewReceiptTracker
+//
+//	@Description:
+//	@receiver c
+//	@param confirmations blocks on top of the tx block, 1 means the tx is mined
+//	@param timeout the longest time Wait polls
+//	@return *ReceiptTracker
+func (c *Proxy) NewReceiptTracker(confirmations uint64, timeout time.Duration) *ReceiptTracker {
+	if confirmations == 0 {
+		confirmations = 1
+	}
+	return &ReceiptTracker{
+		proxy:         c,
+		confirmations: confirmations,
+		timeout:       timeout,
+		interval:      receiptPollInterval,
+		hashes:        make(map[string]*TxReceipt),
+	}
+}
+
+// Track
+//
+//	@Description: add a sent tx to the tracker
+//	@receiver r
+//	@param tx
+//	@return *TxReceipt updated by Wait
+func (r *ReceiptTracker) Track(tx *BuildTxResult) *TxReceipt {
+	receipt := &TxReceipt{
+		Hash:   tx.TxHex,
+		From:   tx.From,
+		Nonce:  tx.SignedTx.Nonce(),
+		Status: TxPending,
+		sentAt: time.Now(),
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.receipts = append(r.receipts, receipt)
+	r.hashes[receipt.Hash] = receipt
+	return receipt
+}
+
+// OnStuck
+//
+//	@Description: replace the txs which are not mined after the duration, e.g. speed them up
+//	@receiver r
+//	@param after
+//	@param replace returns the replacement tx with the same nonce
+func (r *ReceiptTracker) OnStuck(after time.Duration, replace func(receipt *TxReceipt) (*BuildTxResult, error)) {
+	r.stuckAfter = after
+	r.replace = replace
+}
+
+// Receipts
+//
+//	@Description: every tracked tx in the order of Track
+//	@receiver r
+//	@return []*TxReceipt
+func (r *ReceiptTracker) Receipts() []*TxReceipt {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]*TxReceipt(nil), r.receipts...)
+}
+
+// Wait
+//
+//	@Description: poll until every tracked tx is settled, the ones left when the timeout expires or ctx is done stay pending.
+//	With a websocket the receipts are queried at every new head as well, and the txs announced in the mempool are seen.
+//	@receiver r
+//	@param ctx
+//	@return []*TxReceipt
+func (r *ReceiptTracker) Wait(ctx context.Context) []*TxReceipt {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var heads <-chan *types.Header
+	if r.proxy.Websocket() != "" {
+		heads = r.proxy.Heads(ctx)
+		if pending, err := r.proxy.PendingTxs(ctx); err == nil {
+			go r.watchMempool(ctx, pending)
+		}
+	}
+
+	deadline := time.Now().Add(r.timeout)
+	for {
+		pending := 0
+		for _, receipt := range r.Receipts() {
+			if receipt.Settled() {
+				continue
+			}
+			if err := r.proxy.UpdateReceipt(receipt, r.confirmations); err != nil {
+				pending++
+				continue
+			}
+			if !receipt.Settled() {
+				pending++
+				r.replaceStuck(receipt)
+			}
+		}
+		if pending == 0 || time.Now().After(deadline) {
+			return r.Receipts()
+		}
+		select {
+		case <-time.After(r.interval):
+		case <-heads:
+		case <-ctx.Done():
+			return r.Receipts()
+		}
+	}
+}
+
+// watchMempool marks the tracked txs announced by the node
+func (r *ReceiptTracker) watchMempool(ctx context.Context, pending <-chan common.Hash) {
+	for {
+		select {
+		case hash, ok := <-pending:
+			if !ok {
+				return
+			}
+			r.lock.Lock()
+			receipt := r.hashes[hash.Hex()]
+			r.lock.Unlock()
+			if receipt != nil {
+				receipt.seen.Store(true)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *ReceiptTracker) replaceStuck(receipt *TxReceipt) {
+	if r.replace == nil || receipt.BlockNumber > 0 || time.Since(receipt.sentAt) < r.stuckAfter {
+		return
+	}
+	tx, err := r.replace(receipt)
+	if err != nil {
+		return
+	}
+	receipt.Replaced = append(receipt.Replaced, receipt.Hash)
+	receipt.Hash = tx.TxHex
+	receipt.sentAt = time.Now()
+	receipt.seen.Store(false)
+	r.lock.Lock()
+	r.hashes[tx.TxHex] = receipt
+	r.lock.Unlock()
+}
+
+// UpdateReceipt
+//
+//	@Description: query the chain once and update the status of the receipt
+//	@receiver c
+//	@param receipt
+//	@param confirmations
+//	@return error
+func (c *Proxy) UpdateReceipt(receipt *TxReceipt, confirmations uint64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.Timeout)*time.Second)
+	defer cancel()
+
+	txReceipt, err := c.minedReceipt(ctx, receipt)
+	if err != nil {
+		return err
+	}
+	if txReceipt == nil {
+		// not mined, dropped when the nonce is taken by another mined tx
+		nonce, err := c.RemoteRpcClient.NonceAt(ctx, common.HexToAddress(receipt.From), nil)
+		if err != nil {
+			return err
+		}
+		if nonce <= receipt.Nonce {
+			if c.expired(receipt) {
+				// the nonce is free again
+				receipt.Status = TxDropped
+				c.nonces.Reset(receipt.From)
+			}
+			return nil
+		}
+		// it may be mined between the two queries
+		if txReceipt, err = c.minedReceipt(ctx, receipt); err != nil || txReceipt == nil {
+			if err == nil {
+				receipt.Status = TxDropped
+			}
+			return err
+		}
+	}
+
+	head, err := c.RemoteRpcClient.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	receipt.BlockNumber = txReceipt.BlockNumber.Uint64()
+	receipt.GasUsed = txReceipt.GasUsed
+	if head+1 < receipt.BlockNumber+confirmations {
+		return nil
+	}
+	if txReceipt.Status == types.ReceiptStatusSuccessful {
+		receipt.Status = TxConfirmed
+	} else {
+		receipt.Status = TxReverted
+	}
+	return nil
+}
+
+// expired is whether the submitter gave up on every hash of the receipt
+func (c *Proxy) expired(receipt *TxReceipt) bool {
+	submitter, ok := c.submitter.(expiring)
+	if !ok {
+		return false
+	}
+	for _, hash := range receipt.Hashes() {
+		if !submitter.Expired(common.HexToHash(hash)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Known
+//
+//	@Description: whether the node has one of the txs of the receipt, in its mempool or mined. A send which failed
+//	with a timeout or on some nodes of a pool may have got there anyway.
+//	@receiver c
+//	@param receipt
+//	@return bool
+//	@return error
+func (c *Proxy) Known(receipt *TxReceipt) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.Timeout)*time.Second)
+	defer cancel()
+	for _, hash := range receipt.Hashes() {
+		_, _, err := c.RemoteRpcClient.TransactionByHash(ctx, common.HexToHash(hash))
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// minedReceipt returns the receipt of whichever hash of the receipt is mined, nil when none is
+func (c *Proxy) minedReceipt(ctx context.Context, receipt *TxReceipt) (*types.Receipt, error) {
+	for _, hash := range receipt.Hashes() {
+		txReceipt, err := c.receipt(ctx, hash)
+		if err != nil {
+			return nil, err
+		}
+		if txReceipt != nil {
+			if hash != receipt.Hash {
+				// an earlier tx is mined instead of its replacement
+				replaced := []string{receipt.Hash}
+				for _, h := range receipt.Replaced {
+					if h != hash {
+						replaced = append(replaced, h)
+					}
+				}
+				receipt.Replaced = replaced
+				receipt.Hash = hash
+			}
+			return txReceipt, nil
+		}
+	}
+	return nil, nil
+}
+
+// receipt returns nil without error when the tx is not mined
+func (c *Proxy) receipt(ctx context.Context, hash string) (*types.Receipt, error) {
+	txReceipt, err := c.RemoteRpcClient.TransactionReceipt(ctx, common.HexToHash(hash))
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	return txReceipt, err
+}
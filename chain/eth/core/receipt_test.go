@@ -0,0 +1,108 @@
+package core
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"inscription/chain/eth/core/coretest"
+	"testing"
+	"time"
+)
+
+func TestUpdateReceipt(t *testing.T) {
+	node := coretest.NewNode(t, 100, 0)
+	proxy := dialTestProxy(t, node.URL)
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	confirmed := coretest.SignTx(t, key, 0, 1)
+	reverted := coretest.SignTx(t, key, 1, 1)
+	dropped := coretest.SignTx(t, key, 2, 1)
+	taken := coretest.SignTx(t, key, 2, 2)
+	pending := coretest.SignTx(t, key, 4, 1)
+	node.Revert(reverted.Hash())
+	node.Include(confirmed, reverted, taken, pending)
+	node.Mine()
+
+	tests := []struct {
+		name          string
+		tx            *types.Transaction
+		confirmations uint64
+		status        TxStatus
+		block         uint64
+	}{
+		{"mined", confirmed, 1, TxConfirmed, 101},
+		{"mined and failed", reverted, 1, TxReverted, 101},
+		{"not enough confirmations", confirmed, 3, TxPending, 101},
+		{"nonce taken by another tx", dropped, 1, TxDropped, 0},
+		{"nonce gap", pending, 1, TxPending, 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			receipt := &TxReceipt{Hash: test.tx.Hash().Hex(), From: from, Nonce: test.tx.Nonce(), Status: TxPending}
+			if err := proxy.UpdateReceipt(receipt, test.confirmations); err != nil {
+				t.Fatal(err)
+			}
+			if receipt.Status != test.status || receipt.BlockNumber != test.block {
+				t.Fatalf("status %s at %d, want %s at %d", receipt.Status, receipt.BlockNumber, test.status, test.block)
+			}
+		})
+	}
+
+	// the block of the tx counts as the first confirmation
+	receipt := &TxReceipt{Hash: confirmed.Hash().Hex(), From: from, Nonce: 0, Status: TxPending}
+	for _, want := range []TxStatus{TxPending, TxConfirmed} {
+		node.Head.Add(1)
+		if err := proxy.UpdateReceipt(receipt, 3); err != nil {
+			t.Fatal(err)
+		}
+		if receipt.Status != want {
+			t.Fatalf("head %d: status %s, want %s", node.Head.Load(), receipt.Status, want)
+		}
+	}
+}
+
+func TestReceiptTrackerWait(t *testing.T) {
+	node := coretest.NewNode(t, 100, 0)
+	proxy := dialTestProxy(t, node.URL)
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	tracker := proxy.NewReceiptTracker(2, 5*time.Second)
+	tracker.interval = 10 * time.Millisecond
+	var txs []*types.Transaction
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		tx := coretest.SignTx(t, key, nonce, 1)
+		txs = append(txs, tx)
+		tracker.Track(&BuildTxResult{SignedTx: tx, TxHex: tx.Hash().Hex(), From: from})
+	}
+	node.Revert(txs[1].Hash())
+	// the last one is replaced by a tx the tracker doesn't know
+	node.Include(txs[0], txs[1], coretest.SignTx(t, key, 2, 2))
+
+	go func() {
+		for i := 0; i < 2; i++ {
+			time.Sleep(20 * time.Millisecond)
+			node.Mine()
+		}
+	}()
+	receipts := tracker.Wait(context.Background())
+	want := []TxStatus{TxConfirmed, TxReverted, TxDropped}
+	for k, receipt := range receipts {
+		if receipt.Status != want[k] {
+			t.Fatalf("the %dth tx is %s, want %s", k, receipt.Status, want[k])
+		}
+	}
+	if receipts[0].BlockNumber != 101 || receipts[0].GasUsed != 21000 {
+		t.Fatalf("unexpected receipt %+v", receipts[0])
+	}
+
+	// the ones left when the timeout expires stay pending
+	tracker = proxy.NewReceiptTracker(1, 50*time.Millisecond)
+	tracker.interval = 10 * time.Millisecond
+	tx := coretest.SignTx(t, key, 5, 1)
+	tracker.Track(&BuildTxResult{SignedTx: tx, TxHex: tx.Hash().Hex(), From: from})
+	if receipts = tracker.Wait(context.Background()); receipts[0].Status != TxPending {
+		t.Fatalf("status %s, want pending", receipts[0].Status)
+	}
+}
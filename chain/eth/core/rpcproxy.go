@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"errors"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -11,7 +12,9 @@ import (
 	"inscription/chain/util"
 	"inscription/config"
 	"math/big"
+	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -20,11 +23,16 @@ var chainConnections = make(map[string]*Proxy)
 var lock sync.RWMutex
 
 type Proxy struct {
-	RemoteRpcClient *ethclient.Client
+	RemoteRpcClient Backend
 	Timeout         int64
 	rpcClient       *rpc.Client
 	chainId         *big.Int
 	rpcUrl          string
+	nonces          *NonceManager
+	transport       *limitedTransport
+	pool            *Pool     // the backend when the rpc url lists several nodes
+	submitter       Submitter // sends the txs, nil is eth_sendRawTransaction to the backend
+	wsUrl           string    // follows the chain through the websocket, empty polls
 }
 
 // GetProxy
@@ -73,10 +81,14 @@ func newProxy(rpcUrl string, timeout int64) (chain *Proxy, err error) {
 	if timeout <= 0 {
 		timeout = 60
 	}
+	if urls := SplitRpcUrls(rpcUrl); len(urls) > 1 {
+		return newPoolProxy(rpcUrl, urls, timeout)
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
 	defer cancel()
-	rpcClient, err := rpc.DialContext(ctx, rpcUrl)
+	transport := &limitedTransport{base: http.DefaultTransport}
+	rpcClient, err := rpc.DialOptions(ctx, rpcUrl, rpc.WithHTTPClient(&http.Client{Transport: transport}))
 	if err != nil {
 		return
 	}
@@ -93,11 +105,108 @@ func newProxy(rpcUrl string, timeout int64) (chain *Proxy, err error) {
 		RemoteRpcClient: remoteRpcClient,
 		rpcUrl:          rpcUrl,
 		Timeout:         timeout,
+		transport:       transport,
 	}
+	chain.nonces = NewNonceManager(chain.Nonce)
+	chain.SetWebsocket("")
 	return
 }
 
+// newPoolProxy
+//
+//	@Description: proxy over a pool of the urls, each request to an endpoint has the timeout
+//	so that the requests of the proxy can try every endpoint
+//	@param rpcUrl
+//	@param urls
+//	@param timeout second
+//	@return *Proxy
+//	@return error
+func newPoolProxy(rpcUrl string, urls []string, timeout int64) (*Proxy, error) {
+	total := timeout * int64(len(urls))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(total)*time.Second)
+	defer cancel()
+	pool, err := DialPool(ctx, urls, time.Duration(timeout)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	chainId, _ := pool.ChainID(ctx)
+	chain := &Proxy{
+		chainId:         chainId,
+		RemoteRpcClient: pool,
+		rpcUrl:          rpcUrl,
+		Timeout:         total,
+		pool:            pool,
+	}
+	chain.nonces = NewNonceManager(chain.Nonce)
+	return chain, nil
+}
+
+// SplitRpcUrls
+//
+//	@Description: the urls of the rpc url, several are separated by commas
+//	@param rpcUrl
+//	@return []string
+func SplitRpcUrls(rpcUrl string) []string {
+	var urls []string
+	for _, url := range strings.Split(rpcUrl, ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// Pool
+//
+//	@Description: the pool of the nodes behind the proxy
+//	@receiver c
+//	@return *Pool nil when the proxy has a single node
+func (c *Proxy) Pool() *Pool {
+	return c.pool
+}
+
+// SetSubmitter
+//
+//	@Description: send the txs of the proxy through the submitter, the one set before is closed
+//	@receiver c
+//	@param submitter nil sends them to the backend
+func (c *Proxy) SetSubmitter(submitter Submitter) {
+	if c.submitter != nil {
+		c.submitter.Close()
+	}
+	c.submitter = submitter
+}
+
+// Nonces
+//
+//	@Description: the local nonce manager shared by every tx sent through the proxy
+//	@receiver c
+//	@return *NonceManager
+func (c *Proxy) Nonces() *NonceManager {
+	return c.nonces
+}
+
+// SetRateLimit
+//
+//	@Description: limit the http requests sent to the node or to each node of the pool, shared by every user of the proxy
+//	@receiver c
+//	@param rps requests per second, 0 is unlimited
+func (c *Proxy) SetRateLimit(rps float64) {
+	if c.pool != nil {
+		c.pool.setRateLimit(rps)
+		return
+	}
+	if rps <= 0 {
+		c.transport.limiter.Store(nil)
+		return
+	}
+	c.transport.limiter.Store(NewRateLimiter(rps))
+}
+
 func (c *Proxy) Close() {
+	if c.submitter != nil {
+		c.submitter.Close()
+	}
 	if c.RemoteRpcClient != nil {
 		c.RemoteRpcClient.Close()
 	}
@@ -135,6 +244,51 @@ func (c *Proxy) EstimateGasLimit(msg *CallMsg) (gas string, err error) {
 	return gasString, nil
 }
 
+// EstimateGas
+//
+//	@Description: gas used by the message estimated by the node, unlike EstimateGasLimit it isn't raised
+//	@receiver c
+//	@param msg
+//	@return uint64
+//	@return error
+func (c *Proxy) EstimateGas(msg *CallMsg) (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.Timeout)*time.Second)
+	defer cancel()
+	return c.RemoteRpcClient.EstimateGas(ctx, msg.Msg)
+}
+
+// Call
+//
+//	@Description: execute the message against the pending state without sending a tx
+//	@receiver c
+//	@param msg
+//	@return []byte return data
+//	@return error see RevertData for the revert data of a reverted call
+func (c *Proxy) Call(msg *CallMsg) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.Timeout)*time.Second)
+	defer cancel()
+	return c.RemoteRpcClient.PendingCallContract(ctx, msg.Msg)
+}
+
+// RevertData
+//
+//	@Description: revert data carried by the error of a call or a gas estimation
+//	@param err
+//	@return []byte
+//	@return bool false when the error carries no revert data
+func RevertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+	hex, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return nil, false
+	}
+	data, decodeErr := util.HexDecodeString(hex)
+	return data, decodeErr == nil
+}
+
 func (c *Proxy) Nonce(spenderAddressHex string) (uint64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.Timeout)*time.Second)
 	defer cancel()
@@ -146,14 +300,13 @@ func (c *Proxy) Nonce(spenderAddressHex string) (uint64, error) {
 }
 
 func (c *Proxy) BuildTxUnSign(address string, transaction *Transaction) (*types.Transaction, error) {
-	if transaction.Nonce == "" || transaction.Nonce == "0" {
+	if transaction.Nonce == "" {
 		if !util.IsValidAddress(address) {
 			return nil, errors.New("address format is error")
 		}
 		nonce, err := c.Nonce(address)
 		if err != nil {
-			nonce = 0
-			err = nil
+			return nil, err
 		}
 		transaction.Nonce = strconv.FormatUint(nonce, 10)
 	}
@@ -164,14 +317,30 @@ func (c *Proxy) BuildTxSign(privateKey *ecdsa.PrivateKey, txNoSign *types.Transa
 	if privateKey == nil || txNoSign == nil {
 		return nil, errors.New("param is empty")
 	}
+	return c.SignTx(NewKeySigner(privateKey), txNoSign)
+}
+
+// SignTx
+//
+//	@Description: sign the tx for the chain of the proxy
+//	@receiver c
+//	@param signer
+//	@param txNoSign
+//	@return *BuildTxResult
+//	@return error
+func (c *Proxy) SignTx(signer Signer, txNoSign *types.Transaction) (*BuildTxResult, error) {
+	if signer == nil || txNoSign == nil {
+		return nil, errors.New("param is empty")
+	}
 
-	signedTx, err := types.SignTx(txNoSign, types.LatestSignerForChainID(c.chainId), privateKey)
+	signedTx, err := signer.SignTx(txNoSign, c.chainId)
 	if err != nil {
 		return nil, err
 	}
 	return &BuildTxResult{
 		SignedTx: signedTx,
 		TxHex:    signedTx.Hash().String(),
+		From:     signer.Address(),
 	}, nil
 }
 
@@ -181,9 +350,52 @@ func (c *Proxy) SendTx(signedTx *types.Transaction) error {
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.Timeout)*time.Second)
 	defer cancel()
-	err := c.RemoteRpcClient.SendTransaction(ctx, signedTx)
+	var err error
+	if c.submitter != nil {
+		err = c.submitter.Submit(ctx, signedTx)
+	} else {
+		err = c.RemoteRpcClient.SendTransaction(ctx, signedTx)
+	}
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+// PendingTransaction
+//
+//	@Description: the tx of the hash, error when it's unknown or already mined
+//	@receiver c
+//	@param hash
+//	@return *types.Transaction
+//	@return error
+func (c *Proxy) PendingTransaction(hash string) (*types.Transaction, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(c.Timeout)*time.Second)
+	defer cancel()
+	tx, isPending, err := c.RemoteRpcClient.TransactionByHash(ctx, common.HexToHash(hash))
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, errors.New("the transaction is not found, it may be replaced or dropped")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !isPending {
+		return nil, errors.New("the transaction is already mined")
+	}
+	return tx, nil
+}
+
+// Sender
+//
+//	@Description: address which signed the tx
+//	@receiver c
+//	@param tx
+//	@return string
+//	@return error
+func (c *Proxy) Sender(tx *types.Transaction) (string, error) {
+	from, err := types.Sender(types.LatestSignerForChainID(c.chainId), tx)
+	if err != nil {
+		return "", err
+	}
+	return from.Hex(), nil
+}
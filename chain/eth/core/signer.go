@@ -0,0 +1,81 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+	"os"
+)
+
+// Signer signs the txs of one address without exposing its private key
+type Signer interface {
+	Address() string
+	SignTx(tx *types.Transaction, chainId *big.Int) (*types.Transaction, error)
+}
+
+type keySigner struct {
+	privateKey *ecdsa.PrivateKey
+	address    string
+}
+
+// NewKeySigner
+//
+//	@Description: signer holding the private key in memory
+//	@param privateKey
+//	@return Signer
+func NewKeySigner(privateKey *ecdsa.PrivateKey) Signer {
+	return &keySigner{
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey).Hex(),
+	}
+}
+
+func (s *keySigner) Address() string {
+	return s.address
+}
+
+func (s *keySigner) SignTx(tx *types.Transaction, chainId *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainId), s.privateKey)
+}
+
+// LoadKeystore
+//
+//	@Description: decrypt a geth style encrypted keystore file (Web3 Secret Storage)
+//	@param path
+//	@param passphrase
+//	@return Signer
+//	@return error
+func LoadKeystore(path string, passphrase string) (Signer, error) {
+	keyJson, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keystore.DecryptKey(keyJson, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeySigner(key.PrivateKey), nil
+}
+
+// ImportKeystore
+//
+//	@Description: encrypt the private key into a new keystore file of the directory
+//	@param dir
+//	@param privateKey
+//	@param passphrase
+//	@return path of the keystore file
+//	@return err
+func ImportKeystore(dir string, privateKey *ecdsa.PrivateKey, passphrase string) (path string, err error) {
+	if passphrase == "" {
+		return "", errors.New("passphrase can't be empty")
+	}
+	ks := keystore.NewKeyStore(dir, keystore.StandardScryptN, keystore.StandardScryptP)
+	account, err := ks.ImportECDSA(privateKey, passphrase)
+	if err != nil {
+		return "", err
+	}
+	return account.URL.Path, nil
+}
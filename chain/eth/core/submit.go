@@ -0,0 +1,356 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"inscription/config"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// BundlePollInterval is the time between two checks of the head for the bundles to resubmit
+const BundlePollInterval = 2 * time.Second
+
+// Submitter sends the signed txs to the network
+type Submitter interface {
+	Submit(ctx context.Context, tx *types.Transaction) error
+	Close()
+}
+
+// expiring is a submitter which gives up on the txs not mined in time
+type expiring interface {
+	Expired(hash common.Hash) bool
+}
+
+// NewSubmitter
+//
+//	@Description: submitter of the submission config, the private and bundle ones talk to the relay
+//	and read the head and the receipts from the backend
+//	@param submission
+//	@param backend
+//	@return Submitter
+//	@return error
+func NewSubmitter(submission *config.Submission, backend Backend) (Submitter, error) {
+	if err := submission.Validate(); err != nil {
+		return nil, err
+	}
+	if submission.Submitter == config.SubmitterPublic || submission.Submitter == "" {
+		return &PublicSubmitter{backend: backend}, nil
+	}
+
+	var key *ecdsa.PrivateKey
+	var err error
+	if submission.RelayKey == "" {
+		key, err = crypto.GenerateKey()
+	} else {
+		key, err = PrivateKeyToECDSA(submission.RelayKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("relay key: %w", err)
+	}
+	relay, err := rpc.DialOptions(context.Background(), submission.RelayUrl, rpc.WithHTTPClient(&http.Client{
+		Transport: &signingTransport{base: http.DefaultTransport, key: key},
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := uint64(submission.RelayBlocks)
+	if submission.Submitter == config.SubmitterPrivate {
+		return &PrivateSubmitter{relay: relay, backend: backend, blocks: blocks}, nil
+	}
+	return &BundleSubmitter{relay: relay, backend: backend, blocks: blocks, interval: BundlePollInterval, stop: make(chan struct{})}, nil
+}
+
+// PublicSubmitter sends the txs with eth_sendRawTransaction to the nodes of the backend
+type PublicSubmitter struct {
+	backend Backend
+}
+
+func (s *PublicSubmitter) Submit(ctx context.Context, tx *types.Transaction) error {
+	return s.backend.SendTransaction(ctx, tx)
+}
+
+func (s *PublicSubmitter) Close() {}
+
+// PrivateSubmitter sends the txs with eth_sendPrivateTransaction to the relay, which gives them to the builders
+// without the public mempool until the max block
+type PrivateSubmitter struct {
+	relay   *rpc.Client
+	backend Backend
+	blocks  uint64
+}
+
+func (s *PrivateSubmitter) Submit(ctx context.Context, tx *types.Transaction) error {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	head, err := s.backend.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	var hash interface{}
+	return s.relay.CallContext(ctx, &hash, "eth_sendPrivateTransaction", map[string]interface{}{
+		"tx":             hexutil.Encode(raw),
+		"maxBlockNumber": hexutil.EncodeUint64(head + s.blocks),
+	})
+}
+
+func (s *PrivateSubmitter) Close() {
+	s.relay.Close()
+}
+
+// BundleSubmitter sends the txs of an account with consecutive nonces together as one bundle with eth_sendBundle
+// to the relay for the next block, since a builder only includes a tx whose nonce is the one of the account.
+// A bundle is resubmitted for the following blocks until it's mined or it has targeted the blocks,
+// the txs of a bundle which is never mined are expired.
+type BundleSubmitter struct {
+	relay    *rpc.Client
+	backend  Backend
+	blocks   uint64
+	interval time.Duration
+
+	lock    sync.Mutex // held for the whole submission so that the bundles of an account are built in turn
+	pending []*bundle
+	expired map[common.Hash]bool
+	start   sync.Once
+	stop    chan struct{}
+	close   sync.Once
+}
+
+// bundle is the submitted txs of an account in the order of their nonces, waiting to be mined
+type bundle struct {
+	from   common.Address
+	txs    []*types.Transaction
+	target uint64 // the block it's submitted for
+	left   uint64 // the target blocks left
+}
+
+func (b *bundle) first() uint64 {
+	return b.txs[0].Nonce()
+}
+
+func (b *bundle) last() uint64 {
+	return b.txs[len(b.txs)-1].Nonce()
+}
+
+func (s *BundleSubmitter) Submit(ctx context.Context, tx *types.Transaction) error {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return err
+	}
+	head, err := s.backend.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	// the tx joins the bundles of the account right before and after its nonce, or the one with its nonce
+	var joined []*bundle
+	var before, after []*types.Transaction
+	for _, b := range s.pending {
+		if b.from != from {
+			continue
+		}
+		switch {
+		case b.first() <= tx.Nonce() && tx.Nonce() <= b.last():
+			// a replacement takes the place of the tx with its nonce
+			before, after = b.txs[:tx.Nonce()-b.first()], b.txs[tx.Nonce()-b.first()+1:]
+		case b.last()+1 == tx.Nonce():
+			before = b.txs
+		case b.first() == tx.Nonce()+1:
+			after = b.txs
+		default:
+			continue
+		}
+		joined = append(joined, b)
+	}
+	txs := make([]*types.Transaction, 0, len(before)+1+len(after))
+	txs = append(append(append(txs, before...), tx), after...)
+	if err = s.sendBundle(ctx, txs, head+1); err != nil {
+		return err
+	}
+
+	pending := s.pending[:0]
+	for _, b := range s.pending {
+		if !contains(joined, b) {
+			pending = append(pending, b)
+		}
+	}
+	s.pending = append(pending, &bundle{from: from, txs: txs, target: head + 1, left: s.blocks - 1})
+	delete(s.expired, tx.Hash())
+	s.start.Do(func() { go s.loop() })
+	return nil
+}
+
+// Pending
+//
+//	@Description: the number of bundles waiting to be mined or resubmitted
+//	@receiver s
+//	@return int
+func (s *BundleSubmitter) Pending() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return len(s.pending)
+}
+
+// Expired
+//
+//	@Description: whether the tx was submitted for every target block and never mined
+//	@receiver s
+//	@param hash
+//	@return bool
+func (s *BundleSubmitter) Expired(hash common.Hash) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.expired[hash]
+}
+
+// Close stops the resubmission, the bundles already sent may still be mined
+func (s *BundleSubmitter) Close() {
+	s.close.Do(func() {
+		close(s.stop)
+		s.relay.Close()
+	})
+}
+
+func (s *BundleSubmitter) sendBundle(ctx context.Context, txs []*types.Transaction, target uint64) error {
+	raws := make([]string, 0, len(txs))
+	for _, tx := range txs {
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		raws = append(raws, hexutil.Encode(raw))
+	}
+	var result interface{}
+	return s.relay.CallContext(ctx, &result, "eth_sendBundle", map[string]interface{}{
+		"txs":         raws,
+		"blockNumber": hexutil.EncodeUint64(target),
+	})
+}
+
+func (s *BundleSubmitter) loop() {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.resubmit()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// resubmit sends the txs not mined yet of the bundles whose target block has passed for the next block,
+// the bundles without target blocks left are expired
+func (s *BundleSubmitter) resubmit() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.interval)
+	defer cancel()
+	head, err := s.backend.BlockNumber(ctx)
+	if err != nil {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	pending := s.pending[:0]
+	for _, b := range s.pending {
+		if b.target > head {
+			pending = append(pending, b)
+			continue
+		}
+		// the txs are mined in the order of their nonces
+		for len(b.txs) > 0 {
+			receipt, err := s.backend.TransactionReceipt(ctx, b.txs[0].Hash())
+			if err != nil || receipt == nil {
+				break
+			}
+			b.txs = b.txs[1:]
+		}
+		if len(b.txs) == 0 {
+			continue
+		}
+		if b.left == 0 {
+			if s.expired == nil {
+				s.expired = make(map[common.Hash]bool)
+			}
+			for _, tx := range b.txs {
+				s.expired[tx.Hash()] = true
+			}
+			continue
+		}
+		// a failed resubmission is tried again at the next tick
+		if s.sendBundle(ctx, b.txs, head+1) == nil {
+			b.target = head + 1
+			b.left--
+		}
+		pending = append(pending, b)
+	}
+	s.pending = pending
+}
+
+func contains(bundles []*bundle, b *bundle) bool {
+	for _, joined := range bundles {
+		if joined == b {
+			return true
+		}
+	}
+	return false
+}
+
+// signingTransport signs the body of every request to the relay in the X-Flashbots-Signature header
+type signingTransport struct {
+	base http.RoundTripper
+	key  *ecdsa.PrivateKey
+}
+
+func (t *signingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil {
+		return nil, errors.New("the relay request has no body")
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := RelaySignature(t.key, body)
+	if err != nil {
+		return nil, err
+	}
+	signed := req.Clone(req.Context())
+	signed.Body = io.NopCloser(bytes.NewReader(body))
+	signed.Header.Set("X-Flashbots-Signature", signature)
+	return t.base.RoundTrip(signed)
+}
+
+// RelaySignature
+//
+//	@Description: the value of the X-Flashbots-Signature header of the body, the address of the key
+//	and its EIP-191 signature of the hex keccak256 of the body
+//	@param key
+//	@param body
+//	@return string
+//	@return error
+func RelaySignature(key *ecdsa.PrivateKey, body []byte) (string, error) {
+	hash := hexutil.Encode(crypto.Keccak256(body))
+	signature, err := crypto.Sign(accounts.TextHash([]byte(hash)), key)
+	if err != nil {
+		return "", err
+	}
+	return crypto.PubkeyToAddress(key.PublicKey).Hex() + ":" + hexutil.Encode(signature), nil
+}
@@ -0,0 +1,207 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"inscription/chain/eth/core/coretest"
+	"inscription/config"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// lastCall is the first param of the last call of the method and the signature header of the last request
+func lastCall(t *testing.T, node *coretest.Node, method string) (map[string]interface{}, string, []byte) {
+	params := node.LastCall(method)
+	if len(params) == 0 {
+		t.Fatalf("no call of %s", method)
+	}
+	var param map[string]interface{}
+	if err := json.Unmarshal(params[0], &param); err != nil {
+		t.Fatal(err)
+	}
+	signature, body := node.LastRequest()
+	return param, signature, body
+}
+
+func testSubmitter(t *testing.T, submitter string, blocks int) (Submitter, *coretest.Node, *coretest.Node, *types.Transaction) {
+	chain := coretest.NewNode(t, 100, 0)
+	relay := coretest.NewNode(t, 0, 0)
+	backend, err := ethclient.Dial(chain.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(backend.Close)
+	s, err := NewSubmitter(&config.Submission{Submitter: submitter, RelayUrl: relay.URL, RelayBlocks: blocks}, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(s.Close)
+
+	key, _ := crypto.GenerateKey()
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Gas: 21000, GasPrice: big.NewInt(1)}), types.LatestSignerForChainID(big.NewInt(1337)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, chain, relay, tx
+}
+
+func TestPrivateSubmitter(t *testing.T) {
+	s, chain, relay, tx := testSubmitter(t, config.SubmitterPrivate, 25)
+	if err := s.Submit(context.Background(), tx); err != nil {
+		t.Fatal(err)
+	}
+	if chain.Count("eth_sendRawTransaction") != 0 {
+		t.Fatal("a private tx shouldn't reach the public nodes")
+	}
+	param, signature, body := lastCall(t, relay, "eth_sendPrivateTransaction")
+	raw, _ := tx.MarshalBinary()
+	if param["tx"] != hexutil.Encode(raw) || param["maxBlockNumber"] != "0x7d" {
+		t.Fatalf("unexpected params %v", param)
+	}
+
+	// the signature header recovers to its address
+	address, sig, ok := strings.Cut(signature, ":")
+	if !ok {
+		t.Fatalf("invalid signature header %q", signature)
+	}
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte(hexutil.Encode(crypto.Keccak256(body)))), hexutil.MustDecode(sig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if crypto.PubkeyToAddress(*pub).Hex() != address {
+		t.Fatalf("the signature recovers %s, want %s", crypto.PubkeyToAddress(*pub).Hex(), address)
+	}
+}
+
+func TestBundleSubmitter(t *testing.T) {
+	s, chain, relay, tx := testSubmitter(t, config.SubmitterBundle, 2)
+	bundles := s.(*BundleSubmitter)
+	bundles.interval = 10 * time.Millisecond
+
+	if err := s.Submit(context.Background(), tx); err != nil {
+		t.Fatal(err)
+	}
+	if param, _, _ := lastCall(t, relay, "eth_sendBundle"); param["blockNumber"] != "0x65" {
+		t.Fatalf("unexpected target block %v", param["blockNumber"])
+	}
+
+	// the tx isn't mined in the target block, it's resubmitted for the next one
+	chain.Head.Store(101)
+	waitFor(t, func() bool { return relay.Count("eth_sendBundle") == 2 })
+	if param, _, _ := lastCall(t, relay, "eth_sendBundle"); param["blockNumber"] != "0x66" {
+		t.Fatalf("unexpected target block %v", param["blockNumber"])
+	}
+
+	// every target block has passed
+	chain.Head.Store(102)
+	waitFor(t, func() bool { return bundles.Pending() == 0 })
+	if relay.Count("eth_sendBundle") != 2 {
+		t.Fatalf("%d bundles sent, want 2", relay.Count("eth_sendBundle"))
+	}
+	if !bundles.Expired(tx.Hash()) {
+		t.Fatal("the tx never mined should be expired")
+	}
+}
+
+func TestBundleSubmitterNonces(t *testing.T) {
+	s, chain, relay, _ := testSubmitter(t, config.SubmitterBundle, 2)
+	bundles := s.(*BundleSubmitter)
+	bundles.interval = 10 * time.Millisecond
+
+	key, _ := crypto.GenerateKey()
+	var txs []*types.Transaction
+	for nonce := uint64(0); nonce < 2; nonce++ {
+		tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: nonce, Gas: 21000, GasPrice: big.NewInt(1)}), types.LatestSignerForChainID(big.NewInt(1337)), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txs = append(txs, tx)
+	}
+	bundleTxs := func() []interface{} {
+		param, _, _ := lastCall(t, relay, "eth_sendBundle")
+		return param["txs"].([]interface{})
+	}
+	raw := func(tx *types.Transaction) string {
+		b, _ := tx.MarshalBinary()
+		return hexutil.Encode(b)
+	}
+
+	// the second nonce arrives first, the first one joins it in the same bundle
+	if err := s.Submit(context.Background(), txs[1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Submit(context.Background(), txs[0]); err != nil {
+		t.Fatal(err)
+	}
+	if sent := bundleTxs(); len(sent) != 2 || sent[0] != raw(txs[0]) || sent[1] != raw(txs[1]) {
+		t.Fatalf("the bundle should have both txs in the order of the nonces, got %v", sent)
+	}
+	if bundles.Pending() != 1 {
+		t.Fatalf("%d bundles pending, want 1", bundles.Pending())
+	}
+
+	// the first tx is mined in the target block, the second one is resubmitted alone
+	chain.Include(txs[0])
+	chain.Mine()
+	waitFor(t, func() bool { return relay.Count("eth_sendBundle") == 3 })
+	if sent := bundleTxs(); len(sent) != 1 || sent[0] != raw(txs[1]) {
+		t.Fatalf("only the tx not mined should be resubmitted, got %v", sent)
+	}
+
+	chain.Head.Store(102)
+	waitFor(t, func() bool { return bundles.Pending() == 0 })
+	if bundles.Expired(txs[0].Hash()) || !bundles.Expired(txs[1].Hash()) {
+		t.Fatal("only the tx never mined should be expired")
+	}
+
+	// the tracker reports the expired tx as dropped and its nonce is read again
+	proxy := &Proxy{RemoteRpcClient: bundles.backend, Timeout: 1, submitter: bundles}
+	proxy.nonces = NewNonceManager(proxy.Nonce)
+	from := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	if _, err := proxy.nonces.Next(from); err != nil {
+		t.Fatal(err)
+	}
+	for k, want := range []TxStatus{TxConfirmed, TxDropped} {
+		receipt := &TxReceipt{Hash: txs[k].Hash().Hex(), From: from, Nonce: txs[k].Nonce(), Status: TxPending}
+		if err := proxy.UpdateReceipt(receipt, 1); err != nil {
+			t.Fatal(err)
+		}
+		if receipt.Status != want {
+			t.Fatalf("the %dth tx is %s, want %s", k, receipt.Status, want)
+		}
+	}
+	if nonce, err := proxy.nonces.Next(from); err != nil || nonce != 1 {
+		t.Fatalf("the nonce of the expired tx should be used again, got %d, %v", nonce, err)
+	}
+}
+
+func waitFor(t *testing.T, done func() bool) {
+	deadline := time.Now().Add(2 * time.Second)
+	for !done() {
+		if time.Now().After(deadline) {
+			t.Fatal("timeout")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestNewSubmitter(t *testing.T) {
+	if _, err := NewSubmitter(&config.Submission{Submitter: config.SubmitterBundle, RelayBlocks: 1}, nil); err == nil {
+		t.Fatal("expect an error without a relay url")
+	}
+	if _, err := NewSubmitter(&config.Submission{Submitter: "mempool"}, nil); err == nil {
+		t.Fatal("expect an error for an unknown submitter")
+	}
+	if s, err := NewSubmitter(&config.Submission{}, nil); err != nil {
+		t.Fatal(err)
+	} else if _, ok := s.(*PublicSubmitter); !ok {
+		t.Fatal("the default submitter should be public")
+	}
+}
@@ -0,0 +1,255 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// HeadPollInterval is the interval between two head queries without a websocket
+	HeadPollInterval = time.Second
+	// minBackoff and maxBackoff bound the wait before dialing the websocket again after a drop
+	minBackoff = time.Second
+	maxBackoff = 30 * time.Second
+)
+
+// ErrNoWebsocket is returned by the subscriptions which have no polling fallback when the proxy has no websocket
+var ErrNoWebsocket = errors.New("the subscription needs a websocket url")
+
+// IsWebsocket
+//
+//	@Description: whether the url is a websocket one
+//	@param url
+//	@return bool
+func IsWebsocket(url string) bool {
+	return strings.HasPrefix(url, "ws://") || strings.HasPrefix(url, "wss://")
+}
+
+// SetWebsocket
+//
+//	@Description: follow the chain through the websocket url instead of polling
+//	@receiver c
+//	@param url empty polls, unless the rpc url is a single websocket one
+func (c *Proxy) SetWebsocket(url string) {
+	if url == "" && IsWebsocket(c.rpcUrl) && len(SplitRpcUrls(c.rpcUrl)) == 1 {
+		url = c.rpcUrl
+	}
+	c.wsUrl = url
+}
+
+// Websocket
+//
+//	@Description: the websocket url the proxy follows the chain with
+//	@receiver c
+//	@return string empty when it polls
+func (c *Proxy) Websocket() string {
+	return c.wsUrl
+}
+
+// Heads
+//
+//	@Description: the new heads of the chain until ctx is done, from a newHeads subscription over the websocket,
+//	dialed again and resubscribed when it drops. The head is polled without a websocket, while the websocket is down
+//	or when the node doesn't support subscriptions. The current head comes first, then a head is only delivered
+//	when it's higher than the last one.
+//	@receiver c
+//	@param ctx
+//	@return <-chan *types.Header closed when ctx is done
+func (c *Proxy) Heads(ctx context.Context) <-chan *types.Header {
+	heads := make(chan *types.Header, 16)
+	feed := &headFeed{out: heads}
+	go func() {
+		// heads is closed once the forwarder can't send to it anymore
+		var forwarder sync.WaitGroup
+		defer close(heads)
+		defer forwarder.Wait()
+		if c.wsUrl == "" {
+			c.pollHeads(ctx, feed, 0)
+			return
+		}
+
+		// the subscription only announces the heads after the current one
+		c.currentHead(ctx, feed)
+		raw := make(chan *types.Header, 16)
+		forwarder.Add(1)
+		go func() {
+			defer forwarder.Done()
+			for {
+				select {
+				case head := <-raw:
+					feed.send(ctx, head)
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		err := c.resubscribe(ctx, func(client *rpc.Client) (ethereum.Subscription, error) {
+			return ethclient.NewClient(client).SubscribeNewHead(ctx, raw)
+		}, func(ctx context.Context, d time.Duration) {
+			c.pollHeads(ctx, feed, d)
+		})
+		if err != nil {
+			c.pollHeads(ctx, feed, 0)
+		}
+	}()
+	return heads
+}
+
+// PendingTxs
+//
+//	@Description: the hashes of the txs entering the mempool of the node until ctx is done, from a
+//	newPendingTransactions subscription over the websocket, dialed again and resubscribed when it drops
+//	@receiver c
+//	@param ctx
+//	@return <-chan common.Hash closed when ctx is done
+//	@return error ErrNoWebsocket, or the error of the node when it doesn't support the subscription
+func (c *Proxy) PendingTxs(ctx context.Context) (<-chan common.Hash, error) {
+	if c.wsUrl == "" {
+		return nil, ErrNoWebsocket
+	}
+	// the first subscription tells whether the node supports it
+	client, err := rpc.DialContext(ctx, c.wsUrl)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make(chan common.Hash, 256)
+	sub, err := client.EthSubscribe(ctx, hashes, "newPendingTransactions")
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	out := make(chan common.Hash, 256)
+	// out is closed once the forwarder can't send to it anymore
+	var forwarder sync.WaitGroup
+	forwarder.Add(1)
+	go func() {
+		defer forwarder.Done()
+		for {
+			select {
+			case hash := <-hashes:
+				select {
+				case out <- hash:
+				default:
+					// a busy mempool mustn't block the subscription, the hash is only a hint
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		defer close(out)
+		defer forwarder.Wait()
+		select {
+		case <-sub.Err():
+		case <-ctx.Done():
+		}
+		sub.Unsubscribe()
+		client.Close()
+		c.resubscribe(ctx, func(client *rpc.Client) (ethereum.Subscription, error) {
+			return client.EthSubscribe(ctx, hashes, "newPendingTransactions")
+		}, sleepFor)
+	}()
+	return out, nil
+}
+
+// resubscribe keeps a subscription over the websocket until ctx is done, it's dialed again after a drop
+// with a growing backoff during which down runs
+func (c *Proxy) resubscribe(ctx context.Context, subscribe func(client *rpc.Client) (ethereum.Subscription, error), down func(ctx context.Context, d time.Duration)) error {
+	backoff := minBackoff
+	for ctx.Err() == nil {
+		client, err := rpc.DialContext(ctx, c.wsUrl)
+		if err == nil {
+			var sub ethereum.Subscription
+			if sub, err = subscribe(client); err == nil {
+				backoff = minBackoff
+				select {
+				case <-sub.Err():
+				case <-ctx.Done():
+				}
+				sub.Unsubscribe()
+				client.Close()
+				continue
+			}
+			client.Close()
+			if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+				return err
+			}
+		}
+		down(ctx, backoff)
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+	return nil
+}
+
+// headFeed delivers the heads higher than the last one, the subscription and the polling send to it at the same time
+type headFeed struct {
+	lock sync.Mutex
+	out  chan<- *types.Header
+	last uint64
+}
+
+// send holds the lock until the head is delivered so that the heads go out in order
+func (f *headFeed) send(ctx context.Context, head *types.Header) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if head == nil || head.Number == nil || head.Number.Uint64() <= f.last {
+		return
+	}
+	f.last = head.Number.Uint64()
+	select {
+	case f.out <- head:
+	case <-ctx.Done():
+	}
+}
+
+// pollHeads queries the head every HeadPollInterval for the duration, 0 is until ctx is done
+func (c *Proxy) pollHeads(ctx context.Context, feed *headFeed, d time.Duration) {
+	if d > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d)
+		defer cancel()
+	}
+	ticker := time.NewTicker(HeadPollInterval)
+	defer ticker.Stop()
+	for {
+		c.currentHead(ctx, feed)
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// currentHead queries the head once
+func (c *Proxy) currentHead(ctx context.Context, feed *headFeed) {
+	if c.RemoteRpcClient == nil {
+		return
+	}
+	query, cancel := context.WithTimeout(ctx, time.Duration(c.Timeout)*time.Second)
+	defer cancel()
+	if head, err := c.RemoteRpcClient.HeaderByNumber(query, nil); err == nil {
+		feed.send(ctx, head)
+	}
+}
+
+func sleepFor(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+}
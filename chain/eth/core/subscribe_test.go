@@ -0,0 +1,276 @@
+package core
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"inscription/chain/eth/core/coretest"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// wsNode serves the newHeads and newPendingTransactions subscriptions over a websocket,
+// every connection has a server of its own so that drop closes the live ones
+type wsNode struct {
+	lock    sync.Mutex
+	servers []*rpc.Server
+	subs    map[string][]*subscriber
+}
+
+type subscriber struct {
+	notifier *rpc.Notifier
+	id       rpc.ID
+}
+
+// wsService is the eth namespace of the node
+type wsService struct {
+	node *wsNode
+}
+
+func (s *wsService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	return s.node.subscribe(ctx, "newHeads")
+}
+
+func (s *wsService) NewPendingTransactions(ctx context.Context) (*rpc.Subscription, error) {
+	return s.node.subscribe(ctx, "newPendingTransactions")
+}
+
+func newWsNode(t *testing.T) (*wsNode, string) {
+	node := &wsNode{subs: make(map[string][]*subscriber)}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rpcServer := rpc.NewServer()
+		rpcServer.RegisterName("eth", &wsService{node: node})
+		node.lock.Lock()
+		node.servers = append(node.servers, rpcServer)
+		node.lock.Unlock()
+		rpcServer.WebsocketHandler([]string{"*"}).ServeHTTP(w, r)
+	}))
+	t.Cleanup(func() {
+		node.drop()
+		server.Close()
+	})
+	return node, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func (n *wsNode) subscribe(ctx context.Context, name string) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	n.lock.Lock()
+	n.subs[name] = append(n.subs[name], &subscriber{notifier: notifier, id: sub.ID})
+	n.lock.Unlock()
+	return sub, nil
+}
+
+// count is the number of subscriptions made to the name
+func (n *wsNode) count(name string) int {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return len(n.subs[name])
+}
+
+// notify sends the data to the latest subscription of the name
+func (n *wsNode) notify(name string, data interface{}) {
+	n.lock.Lock()
+	subs := n.subs[name]
+	n.lock.Unlock()
+	if len(subs) > 0 {
+		last := subs[len(subs)-1]
+		last.notifier.Notify(last.id, data)
+	}
+}
+
+// drop closes every connection
+func (n *wsNode) drop() {
+	n.lock.Lock()
+	servers := n.servers
+	n.servers = nil
+	n.lock.Unlock()
+	for _, server := range servers {
+		server.Stop()
+	}
+}
+
+func nextHead(t *testing.T, heads <-chan *types.Header) uint64 {
+	select {
+	case head := <-heads:
+		return head.Number.Uint64()
+	case <-time.After(5 * time.Second):
+		t.Fatal("no head")
+		return 0
+	}
+}
+
+func testHeader(number uint64) *types.Header {
+	return &types.Header{Number: new(big.Int).SetUint64(number), Difficulty: big.NewInt(0)}
+}
+
+func TestHeadsResubscribe(t *testing.T) {
+	node, url := newWsNode(t)
+	proxy := &Proxy{Timeout: 1, rpcUrl: url}
+	proxy.SetWebsocket("")
+	if proxy.Websocket() != url {
+		t.Fatalf("the ws rpc url should be the websocket, got %q", proxy.Websocket())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	heads := proxy.Heads(ctx)
+	waitFor(t, func() bool { return node.count("newHeads") == 1 })
+	node.notify("newHeads", testHeader(1))
+	if number := nextHead(t, heads); number != 1 {
+		t.Fatalf("head %d, want 1", number)
+	}
+
+	node.drop()
+	waitFor(t, func() bool { return node.count("newHeads") == 2 })
+	// a head already delivered is skipped
+	node.notify("newHeads", testHeader(1))
+	node.notify("newHeads", testHeader(2))
+	if number := nextHead(t, heads); number != 2 {
+		t.Fatalf("head %d, want 2", number)
+	}
+
+	cancel()
+	for range heads {
+	}
+}
+
+func TestHeadsPolling(t *testing.T) {
+	node := coretest.NewNode(t, 100, 0)
+	client, err := ethclient.Dial(node.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	proxy := &Proxy{RemoteRpcClient: client, Timeout: 1, rpcUrl: node.URL}
+	proxy.SetWebsocket("")
+	if proxy.Websocket() != "" {
+		t.Fatal("an http rpc url has no websocket")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	heads := proxy.Heads(ctx)
+	if number := nextHead(t, heads); number != 100 {
+		t.Fatalf("head %d, want 100", number)
+	}
+	node.Head.Store(101)
+	if number := nextHead(t, heads); number != 101 {
+		t.Fatalf("head %d, want 101", number)
+	}
+}
+
+func TestPendingTxs(t *testing.T) {
+	if _, err := (&Proxy{}).PendingTxs(context.Background()); err != ErrNoWebsocket {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	node, url := newWsNode(t)
+	proxy := &Proxy{Timeout: 1}
+	proxy.SetWebsocket(url)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	hashes, err := proxy.PendingTxs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash := common.HexToHash("0x01")
+	node.notify("newPendingTransactions", hash)
+	select {
+	case got := <-hashes:
+		if got != hash {
+			t.Fatalf("hash %s, want %s", got, hash)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no pending tx")
+	}
+
+	node.drop()
+	waitFor(t, func() bool { return node.count("newPendingTransactions") == 2 })
+}
+
+func TestHeadFeedConcurrentSend(t *testing.T) {
+	heads := make(chan *types.Header, 1)
+	feed := &headFeed{out: heads}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// the subscription and the polling deliver the same heads at the same time
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for number := int64(1); number <= 50; number++ {
+				feed.send(ctx, &types.Header{Number: big.NewInt(number)})
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(heads)
+	}()
+
+	last := uint64(0)
+	for head := range heads {
+		if head.Number.Uint64() <= last {
+			t.Fatalf("head %d delivered after %d", head.Number, last)
+		}
+		last = head.Number.Uint64()
+	}
+	if last != 50 {
+		t.Fatalf("last head %d, want 50", last)
+	}
+}
+
+func TestSubscriptionsCancelUnderLoad(t *testing.T) {
+	node, url := newWsNode(t)
+	proxy := &Proxy{Timeout: 1, rpcUrl: url}
+	proxy.SetWebsocket("")
+
+	for round := 1; round <= 10; round++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		heads := proxy.Heads(ctx)
+		hashes, err := proxy.PendingTxs(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		waitFor(t, func() bool { return node.count("newHeads") == round && node.count("newPendingTransactions") == round })
+
+		// the forwarders are busy when ctx is cancelled, the channels must not be closed under them
+		stop := make(chan struct{})
+		flooded := make(chan struct{})
+		go func() {
+			defer close(flooded)
+			for n := uint64(1); ; n++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				node.notify("newHeads", testHeader(n))
+				node.notify("newPendingTransactions", common.BigToHash(new(big.Int).SetUint64(n)))
+			}
+		}()
+		nextHead(t, heads)
+		<-hashes
+		cancel()
+		for range heads {
+		}
+		for range hashes {
+		}
+		close(stop)
+		<-flooded
+	}
+}
@@ -14,6 +14,7 @@ import (
 type BuildTxResult struct {
 	SignedTx *types.Transaction
 	TxHex    string
+	From     string
 }
 
 // CallMsg contains parameters for contract calls.
@@ -1,5 +1,11 @@
 package util
 
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/crypto"
+	"strings"
+)
+
 // Lengths of hashes and addresses in bytes.
 const (
 	// HashLength is the expected length of the hash
@@ -23,3 +29,51 @@ func IsHexAddress(s string) bool {
 func has0xPrefix(str string) bool {
 	return len(str) >= 2 && str[0] == '0' && (str[1] == 'x' || str[1] == 'X')
 }
+
+// ValidateAddress
+//
+//	@Description: the address must be 20 bytes of hex, a mixed case address must also match its EIP-55 checksum
+//	@param address
+//	@return error
+func ValidateAddress(address string) error {
+	if !IsValidAddress(address) {
+		return fmt.Errorf("invalid hex address %s", address)
+	}
+	hexPart := address
+	if has0xPrefix(hexPart) {
+		hexPart = hexPart[2:]
+	}
+	if hexPart == strings.ToLower(hexPart) || hexPart == strings.ToUpper(hexPart) {
+		// no checksum
+		return nil
+	}
+	if checksum := ToChecksumAddress(address); checksum[2:] != hexPart {
+		return fmt.Errorf("address %s fails its EIP-55 checksum, expect %s", address, checksum)
+	}
+	return nil
+}
+
+// ToChecksumAddress
+//
+//	@Description: EIP-55 form of the hex address, a hex letter is upper case when its nibble of the keccak256 of the lower case address is 8 or more
+//	@param address
+//	@return string
+func ToChecksumAddress(address string) string {
+	if has0xPrefix(address) {
+		address = address[2:]
+	}
+	lower := strings.ToLower(address)
+	hash := crypto.Keccak256([]byte(lower))
+
+	checksum := []byte(lower)
+	for i, c := range checksum {
+		nibble := hash[i/2] >> 4
+		if i%2 == 1 {
+			nibble = hash[i/2] & 0x0f
+		}
+		if c >= 'a' && c <= 'f' && nibble >= 8 {
+			checksum[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(checksum)
+}
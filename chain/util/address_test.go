@@ -0,0 +1,41 @@
+package util
+
+import "testing"
+
+func TestChecksumAddress(t *testing.T) {
+	// vectors of EIP-55
+	for _, address := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	} {
+		if checksum := ToChecksumAddress(address); checksum != address {
+			t.Fatalf("checksum of %s is %s", address, checksum)
+		}
+		if err := ValidateAddress(address); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	valid := []string{
+		"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		"0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED",
+		"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+	}
+	for _, address := range valid {
+		if err := ValidateAddress(address); err != nil {
+			t.Fatal(err)
+		}
+	}
+	invalid := []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeg",
+	}
+	for _, address := range invalid {
+		if err := ValidateAddress(address); err == nil {
+			t.Fatalf("%s: expect an error", address)
+		}
+	}
+}
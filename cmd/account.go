@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"inscription/app"
+	"inscription/chain/eth/core"
+	"inscription/config"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// wallet is an account of the run with its own times and delay
+type wallet struct {
+	account *core.Account
+	times   int
+	delay   int
+}
+
+// loadWallets
+//
+//	@Description: accounts of the keystore, or of the key file, or of the mnemonic, or of the private key, in that order
+//	@param ins
+//	@return []*wallet
+//	@return error
+func loadWallets(ins *config.Inscription) ([]*wallet, error) {
+	switch {
+	case ins.Keystore != "":
+		return keystoreWallets(ins)
+	case ins.KeyFile != "":
+		keys, err := config.LoadWallets(ins.KeyFile, ins.Times, ins.Delay)
+		if err != nil {
+			return nil, err
+		}
+		wallets := make([]*wallet, 0, len(keys))
+		for i, key := range keys {
+			account, err := core.NewAccount().AccountWithKey(key.Key)
+			if err != nil {
+				return nil, fmt.Errorf("wallet %d: %w", i+1, err)
+			}
+			wallets = append(wallets, &wallet{account: account, times: key.Times, delay: key.Delay})
+		}
+		return wallets, nil
+	case ins.Mnemonic != "":
+		accounts, err := mnemonicAccounts(ins)
+		if err != nil {
+			return nil, err
+		}
+		wallets := make([]*wallet, 0, len(accounts))
+		for _, account := range accounts {
+			wallets = append(wallets, &wallet{account: account, times: ins.Times, delay: ins.Delay})
+		}
+		return wallets, nil
+	case ins.PrivateKey != "":
+		account, err := core.NewAccount().AccountWithKey(ins.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		return []*wallet{{account: account, times: ins.Times, delay: ins.Delay}}, nil
+	default:
+		return nil, errors.New("no account, set key, key-file, keystore or mnemonic")
+	}
+}
+
+// mnemonicAccounts derives the accounts of the index range
+func mnemonicAccounts(ins *config.Inscription) ([]*core.Account, error) {
+	mnemonic := strings.Join(strings.Fields(ins.Mnemonic), " ")
+	return core.NewAccount().AccountsByMnemonic(mnemonic, ins.MnemonicPassphrase, ins.DerivationPath, ins.AccountStart, ins.AccountCount)
+}
+
+// keystoreWallets decrypts the keystore file, or every file of the keystore directory, with one passphrase
+func keystoreWallets(ins *config.Inscription) ([]*wallet, error) {
+	info, err := os.Stat(ins.Keystore)
+	if err != nil {
+		return nil, err
+	}
+	paths := []string{ins.Keystore}
+	if info.IsDir() {
+		entries, err := os.ReadDir(ins.Keystore)
+		if err != nil {
+			return nil, err
+		}
+		paths = paths[:0]
+		for _, entry := range entries {
+			if !entry.IsDir() && entry.Name()[0] != '.' {
+				paths = append(paths, filepath.Join(ins.Keystore, entry.Name()))
+			}
+		}
+		if len(paths) == 0 {
+			return nil, fmt.Errorf("no keystore file in %s", ins.Keystore)
+		}
+	}
+
+	passphrase, err := readPassphrase(ins.PassphraseFile, false)
+	if err != nil {
+		return nil, err
+	}
+	wallets := make([]*wallet, 0, len(paths))
+	for _, path := range paths {
+		signer, err := core.LoadKeystore(path, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		app.LogInfof("unlocked %s", signer.Address())
+		account := core.NewAccount().AccountWithSigner(signer)
+		wallets = append(wallets, &wallet{account: account, times: ins.Times, delay: ins.Delay})
+	}
+	return wallets, nil
+}
+
+// loadAccount
+//
+//	@Description: the only account of the run
+//	@param ins
+//	@return *core.Account
+//	@return error
+func loadAccount(ins *config.Inscription) (*core.Account, error) {
+	wallets, err := loadWallets(ins)
+	if err != nil {
+		return nil, err
+	}
+	if len(wallets) != 1 {
+		return nil, fmt.Errorf("expect one account, got %d", len(wallets))
+	}
+	return wallets[0].account, nil
+}
+
+// walletAccounts
+//
+//	@Description: accounts of the wallets
+//	@param wallets
+//	@return []*core.Account
+func walletAccounts(wallets []*wallet) []*core.Account {
+	accounts := make([]*core.Account, 0, len(wallets))
+	for _, wallet := range wallets {
+		accounts = append(accounts, wallet.account)
+	}
+	return accounts
+}
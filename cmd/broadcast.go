@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"inscription/app"
+	"inscription/chain/eth/core"
+	"inscription/config"
+	"time"
+)
+
+func runBroadcast(args []string) error {
+	in := defaultSignedFile
+	var delay int
+	ins, err := parseConfig("broadcast", args, func(fs *flag.FlagSet, ins *config.Inscription) {
+		fs.StringVar(&in, "in", in, "file of the signed txs, the raw tx in hex one per line")
+		fs.IntVar(&delay, "delay", delay, "the time interval between two txs, second, 0 sends them at once")
+		fs.IntVar(&ins.Confirmations, "confirmations", ins.Confirmations, "blocks to wait for each receipt, 0 doesn't wait")
+		fs.IntVar(&ins.ReceiptTimeout, "receipt-timeout", ins.ReceiptTimeout, "the longest time to wait for the receipts, second")
+	})
+	if err != nil {
+		return err
+	}
+	if ins.RpcUrl == "" {
+		return errors.New("rpc is required")
+	}
+	txs, err := core.ReadRawTxs(in)
+	if err != nil {
+		return err
+	}
+
+	evmApp, err := newApp(ins)
+	if err != nil {
+		return err
+	}
+	chainId := evmApp.ChainID()
+	for i, tx := range txs {
+		if tx.ChainId().Cmp(chainId) != 0 {
+			return fmt.Errorf("%dth tx is signed for the chain %s, the node is on the chain %s", i+1, tx.ChainId(), chainId)
+		}
+	}
+	app.LogInfof("broadcast %d txs of %s through %s", len(txs), in, ins.RpcUrl)
+
+	tracker := evmApp.NewReceiptTracker(ins.Confirmations, ins.ReceiptTimeout)
+	ctx, stop := interruptContext()
+	defer stop()
+	failed := evmApp.Broadcast(ctx, txs, time.Duration(delay)*time.Second, tracker)
+
+	receipts := tracker.Receipts()
+	if ins.Confirmations > 0 {
+		app.LogInfof("waiting for %d confirmations of the receipts", ins.Confirmations)
+		receipts = tracker.Wait(ctx)
+	}
+	app.PrintReceipts(receipts)
+	if failed > 0 {
+		return fmt.Errorf("%d txs are not sent", failed)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+)
+
+type command struct {
+	usage string
+	run   func(args []string) error
+}
+
+var commands = map[string]*command{
+	"mint":       {usage: "send the inscription transactions", run: runMint},
+	"speedup":    {usage: "resend a pending transaction with the same nonce and a higher fee", run: runSpeedUp},
+	"cancel":     {usage: "replace a pending transaction with a 0 value transfer to itself", run: runCancel},
+	"derive":     {usage: "list the addresses and balances derived from a mnemonic", run: runDerive},
+	"distribute": {usage: "send eth from a funding wallet to every minting wallet", run: runDistribute},
+	"sweep":      {usage: "send the leftovers of every minting wallet back to one address", run: runSweep},
+	"drop":       {usage: "inscribe every item of a manifest or a directory, one transaction each", run: runDrop},
+	"import":     {usage: "encrypt a private key or mnemonic into a new keystore file", run: runImport},
+	"transfer":   {usage: "move ethscriptions to a new owner, several ones in one ESIP-5 bulk transfer", run: runTransfer},
+	"resume":     {usage: "continue the last run of the journal with the inscriptions it didn't make", run: runResume},
+	"sign":       {usage: "sign a batch of transactions with sequential nonces into a file, offline given the chain id, nonce and fees", run: runSign},
+	"broadcast":  {usage: "send the transactions of a signed file through one or more rpc urls", run: runBroadcast},
+}
+
+// Execute
+//
+//	@Description: dispatch the args to the subcommand, without any args the interactive mint is started
+//	@param args os.Args without the program name
+//	@return error
+func Execute(args []string) error {
+	if len(args) == 0 {
+		return runInteractiveMint()
+	}
+
+	name := args[0]
+	if name == "help" || name == "-h" || name == "--help" {
+		usage()
+		return nil
+	}
+	c, ok := commands[name]
+	if !ok {
+		usage()
+		return fmt.Errorf("unknown command: %s", name)
+	}
+	err := c.run(args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	return err
+}
+
+func usage() {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(os.Stderr, "usage: inscribe <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprintln(os.Stderr, "\nrun 'inscribe <command> -h' for the flags of a command, run without any command to input interactively")
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"inscription/app"
+	"inscription/config"
+)
+
+// parseConfig
+//
+//	@Description: defaults < config file < flags, the flags shared by every command are registered here
+//	@param name command name
+//	@param args
+//	@param register register the flags of the command, the current values of ins are used as defaults
+//	@return *config.Inscription
+//	@return error
+func parseConfig(name string, args []string, register func(fs *flag.FlagSet, ins *config.Inscription)) (*config.Inscription, error) {
+	return parseConfigOver(name, args, func(*config.Inscription) (*config.Inscription, error) {
+		return config.NewInscription(), nil
+	}, register)
+}
+
+// parseConfigOver
+//
+//	@Description: same as parseConfig with other defaults than config.NewInscription
+//	@param name command name
+//	@param args
+//	@param defaults returns the defaults, it gets the config of a first pass over the flags
+//	@param register
+//	@return *config.Inscription
+//	@return error
+func parseConfigOver(name string, args []string, defaults func(flags *config.Inscription) (*config.Inscription, error), register func(fs *flag.FlagSet, ins *config.Inscription)) (*config.Inscription, error) {
+	newFlags := func(ins *config.Inscription, configPath *string) *flag.FlagSet {
+		fs := flag.NewFlagSet(name, flag.ContinueOnError)
+		fs.StringVar(configPath, "config", *configPath, "json config file, the flags override its fields")
+		fs.StringVar(&ins.RpcUrl, "rpc", ins.RpcUrl, "rpc url of the node, several urls separated by commas make a pool with failover")
+		fs.BoolVar(&ins.Broadcast, "broadcast", ins.Broadcast, "send every tx to all the rpc urls at once")
+		fs.StringVar(&ins.WsUrl, "ws", ins.WsUrl, "websocket url of the node following the new heads and the mempool, polls without it unless the rpc url is a ws one")
+		submissionFlags(fs, &ins.Submission)
+		fs.StringVar(&ins.PrivateKey, "key", ins.PrivateKey, "private key of the account")
+		register(fs, ins)
+		return fs
+	}
+
+	// the first pass only looks for the config file path and whatever the defaults need
+	var configPath string
+	flags := config.NewInscription()
+	if err := newFlags(flags, &configPath).Parse(args); err != nil {
+		return nil, err
+	}
+
+	ins, err := defaults(flags)
+	if err != nil {
+		return nil, err
+	}
+	if configPath != "" {
+		if err := config.LoadInscription(configPath, ins); err != nil {
+			return nil, err
+		}
+	}
+	if err := newFlags(ins, &configPath).Parse(args); err != nil {
+		return nil, err
+	}
+	return ins, nil
+}
+
+// newApp
+//
+//	@Description: app of the rpc url of the config, a pool when it lists several urls
+//	@param ins
+//	@return *app.App
+//	@return error
+func newApp(ins *config.Inscription) (*app.App, error) {
+	evmApp := app.NewApp(ins.RpcUrl, 3)
+	if evmApp == nil {
+		return nil, errors.New("init app failed")
+	}
+	evmApp.SetBroadcast(ins.Broadcast)
+	evmApp.SetWebsocket(ins.WsUrl)
+	if err := evmApp.SetSubmission(&ins.Submission); err != nil {
+		return nil, err
+	}
+	return evmApp, nil
+}
+
+// submissionFlags
+//
+//	@Description: flags of the way the txs are sent
+//	@param fs
+//	@param submission
+func submissionFlags(fs *flag.FlagSet, submission *config.Submission) {
+	fs.StringVar(&submission.Submitter, "submitter", submission.Submitter, "how the txs are sent: public (the rpc nodes), private (eth_sendPrivateTransaction to the relay) or bundle (eth_sendBundle to the relay)")
+	fs.StringVar(&submission.RelayUrl, "relay", submission.RelayUrl, "url of the relay of the private and bundle submitters")
+	fs.StringVar(&submission.RelayKey, "relay-key", submission.RelayKey, "private key signing the requests to the relay, a new one every run when empty")
+	fs.IntVar(&submission.RelayBlocks, "relay-blocks", submission.RelayBlocks, "blocks a private tx may wait for, or target blocks of a bundle")
+}
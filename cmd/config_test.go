@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"flag"
+	"inscription/config"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseConfigOver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"times": 20, "rpcUrl": "http://file"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	register := func(fs *flag.FlagSet, ins *config.Inscription) {
+		fs.IntVar(&ins.Times, "times", ins.Times, "")
+	}
+	defaults := func(*config.Inscription) (*config.Inscription, error) {
+		ins := config.NewInscription()
+		ins.Times = 5
+		return ins, nil
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		times int
+		rpc   string
+	}{
+		{"defaults", nil, 5, ""},
+		{"config file over the defaults", []string{"-config", path}, 20, "http://file"},
+		{"flags over the defaults", []string{"-times", "30", "-rpc", "http://flag"}, 30, "http://flag"},
+		{"flags over the config file", []string{"-config", path, "-times", "30"}, 30, "http://file"},
+		{"flags before the config file still win", []string{"-rpc", "http://flag", "-config", path}, 20, "http://flag"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ins, err := parseConfigOver("test", test.args, defaults, register)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ins.Times != test.times || ins.RpcUrl != test.rpc {
+				t.Fatalf("times %d and rpc %q, want %d and %q", ins.Times, ins.RpcUrl, test.times, test.rpc)
+			}
+			// a field nobody sets keeps the default of config.NewInscription
+			if ins.Confirmations != config.DefaultConfirmations {
+				t.Fatalf("confirmations %d, want the default %d", ins.Confirmations, config.DefaultConfirmations)
+			}
+		})
+	}
+
+	ins, err := parseConfig("test", nil, register)
+	if err != nil || ins.Times != config.DefaultTimes {
+		t.Fatalf("times %d, want the default %d, %v", ins.Times, config.DefaultTimes, err)
+	}
+	if _, err = parseConfigOver("test", []string{"-config", filepath.Join(t.TempDir(), "missing.json")}, defaults, register); err == nil {
+		t.Fatal("expect the error of a missing config file")
+	}
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"inscription/app"
+	"inscription/chain/eth/contract"
+	"inscription/chain/eth/core"
+	"inscription/chain/util"
+	"inscription/config"
+	"strings"
+)
+
+// argsFlag collects the repeated -arg flags
+type argsFlag struct {
+	args *[]string
+}
+
+func (f argsFlag) String() string {
+	if f.args == nil {
+		return ""
+	}
+	return strings.Join(*f.args, " ")
+}
+
+func (f argsFlag) Set(s string) error {
+	*f.args = append(*f.args, s)
+	return nil
+}
+
+// contractFlags
+//
+//	@Description: flags of the contract call mode
+//	@param fs
+//	@param ins
+func contractFlags(fs *flag.FlagSet, ins *config.Inscription) {
+	fs.StringVar(&ins.Contract, "contract", ins.Contract, "mint by calling the contract instead of inscribing, it receives every tx")
+	fs.StringVar(&ins.Abi, "abi", ins.Abi, "ABI json of the contract, a bare array or a build artifact, otherwise -method is a function signature")
+	fs.StringVar(&ins.Method, "method", ins.Method, "function name of the ABI, or a signature like \"mint(uint256 amount, address to) payable\"")
+	fs.Var(argsFlag{&ins.Args}, "arg", "argument of the function in order, repeat it for every argument, arrays are json like [\"1\",\"2\"]")
+	fs.StringVar(&ins.Value, "value", ins.Value, "eth sent with every call")
+}
+
+// contractFunction is the function called by the contract mode, from the ABI or the signature
+func contractFunction(ins *config.Inscription) (*contract.Function, error) {
+	if ins.Abi != "" {
+		return contract.LoadFunction(ins.Abi, ins.Method)
+	}
+	return contract.ParseSignature(ins.Method)
+}
+
+// checkContractCall
+//
+//	@Description: validate the contract call mode and encode the calldata into Data when it's empty
+//	@param ins
+//	@return error
+func checkContractCall(ins *config.Inscription) error {
+	if ins.Text != "" || ins.File != "" || ins.Json != "" || ins.Operation.Op != "" {
+		return errors.New("a contract call can't carry an inscription payload, use -data for raw calldata")
+	}
+	if ins.To != "" || ins.ToFile != "" {
+		return errors.New("the contract receives every call, -to and -to-file can't be used with -contract")
+	}
+	if err := util.ValidateAddress(ins.Contract); err != nil {
+		return err
+	}
+	if ins.Value != "" {
+		if _, err := toWei(ins.Value); err != nil {
+			return err
+		}
+	}
+	if ins.Method == "" {
+		if ins.Data == "" {
+			return errors.New("method or data is required to call the contract")
+		}
+		return nil
+	}
+
+	fn, err := contractFunction(ins)
+	if err != nil {
+		return err
+	}
+	if ins.Value != "" && !fn.Method.IsPayable() {
+		return fmt.Errorf("%s isn't payable, it can't receive value", fn.Method.Sig)
+	}
+	calldata, err := fn.Encode(ins.Args)
+	if err != nil {
+		return err
+	}
+	if ins.Data == "" {
+		ins.Data = util.HexEncodeToString(calldata)
+	}
+	app.LogInfof("contract call: %s.%s(%s)", ins.Contract, fn.Method.Sig, strings.Join(ins.Args, ", "))
+	return nil
+}
+
+// simulateCall
+//
+//	@Description: run the call with eth_call from the account before minting, the gas limit is estimated when empty.
+//	A revert stops the run with its decoded reason.
+//	@param evmApp
+//	@param ins
+//	@param from
+//	@return error
+func simulateCall(evmApp *app.App, ins *config.Inscription, from string) error {
+	fn := &contract.Function{}
+	if ins.Method != "" {
+		var err error
+		if fn, err = contractFunction(ins); err != nil {
+			return err
+		}
+	}
+	value := callValue(ins)
+
+	if _, err := evmApp.Call(from, ins.Contract, value, ins.Data); err != nil {
+		return fmt.Errorf("the simulated call failed: %s", revertReason(fn, err))
+	}
+	app.LogInfof("the simulated call from %s succeeded", from)
+	if ins.GasLimit != "" {
+		return nil
+	}
+	estimate, err := evmApp.EstimateGasLimit(from, ins.Contract, value, ins.Data)
+	if err != nil {
+		return fmt.Errorf("estimate the gas of the call failed: %s", revertReason(fn, err))
+	}
+	ins.GasLimit = estimate
+	return nil
+}
+
+// callValue is the wei sent with every call
+func callValue(ins *config.Inscription) string {
+	if ins.Value == "" {
+		return "0"
+	}
+	wei, _ := toWei(ins.Value)
+	return wei.String()
+}
+
+// revertReason decodes the revert data of the error, or the error itself when it has none
+func revertReason(fn *contract.Function, err error) string {
+	if data, ok := core.RevertData(err); ok {
+		return fn.DecodeRevert(data)
+	}
+	return err.Error()
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"inscription/app"
+	"inscription/chain/eth/core"
+	"inscription/config"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// mnemonicFlags
+//
+//	@Description: flags to derive the wallets from a mnemonic
+//	@param fs
+//	@param ins
+func mnemonicFlags(fs *flag.FlagSet, ins *config.Inscription) {
+	fs.StringVar(&ins.Mnemonic, "mnemonic", ins.Mnemonic, "mnemonic to derive the wallets from, replaces -key")
+	fs.StringVar(&ins.MnemonicPassphrase, "mnemonic-passphrase", ins.MnemonicPassphrase, "optional BIP-39 passphrase of the mnemonic")
+	fs.StringVar(&ins.DerivationPath, "path", ins.DerivationPath, "derivation path template with the placeholder {index}")
+	fs.IntVar(&ins.AccountStart, "start", ins.AccountStart, "first index of the derived accounts")
+	fs.IntVar(&ins.AccountCount, "count", ins.AccountCount, "number of the derived accounts")
+}
+
+func runDerive(args []string) error {
+	ins, err := parseConfig("derive", args, mnemonicFlags)
+	if err != nil {
+		return err
+	}
+	if ins.Mnemonic == "" {
+		if ins.Mnemonic, err = readSecret("please input the mnemonic:"); err != nil {
+			return err
+		}
+	}
+	if ins.Mnemonic == "" {
+		return errors.New("mnemonic is required")
+	}
+	accounts, err := mnemonicAccounts(ins)
+	if err != nil {
+		return err
+	}
+
+	// the balances are only queried with a rpc url
+	var evmApp *app.App
+	if ins.RpcUrl != "" {
+		if evmApp = app.NewApp(ins.RpcUrl, 3); evmApp == nil {
+			return errors.New("init app failed")
+		}
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "INDEX\tPATH\tADDRESS\tBALANCE")
+	for i, account := range accounts {
+		index := ins.AccountStart + i
+		path := strings.ReplaceAll(ins.DerivationPath, core.DerivationIndex, fmt.Sprint(index))
+		balance := "-"
+		if evmApp != nil {
+			balance = balanceOf(evmApp, account.Address)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", index, path, account.Address, balance)
+	}
+	w.Flush()
+	_, err = os.Stdout.Write(buf.Bytes())
+	return err
+}
+
+// balanceOf returns the balance in eth, or the error message
+func balanceOf(evmApp *app.App, address string) string {
+	balanceStr, err := evmApp.BalanceOf(address)
+	if err != nil {
+		return err.Error()
+	}
+	balance, err := decimal.NewFromString(balanceStr)
+	if err != nil {
+		return err.Error()
+	}
+	return balance.DivRound(decimal.New(1, 18), 6).String()
+}
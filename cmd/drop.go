@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"inscription/app"
+	"inscription/chain/eth/core"
+	"inscription/chain/util"
+	"inscription/config"
+	"inscription/ethscription"
+	"inscription/journal"
+	"strings"
+)
+
+// dropFlags
+//
+//	@Description: flags of the drop command
+//	@param fs
+//	@param ins
+func dropFlags(fs *flag.FlagSet, ins *config.Inscription) {
+	keystoreFlags(fs, ins)
+	mnemonicFlags(fs, ins)
+	fs.StringVar(&ins.Manifest, "manifest", ins.Manifest, "directory of files, or .json or .csv manifest of the items, one tx each")
+	feeFlags(fs, &ins.Fee)
+	fs.StringVar(&ins.GasLimit, "gas-limit", ins.GasLimit, "gas limit of every item, estimated for each item when empty")
+	fs.IntVar(&ins.Delay, "delay", ins.Delay, "the time interval between two items, second")
+	fs.Float64Var(&ins.RateLimit, "rate-limit", ins.RateLimit, "requests per second sent to each rpc node, 0 is unlimited")
+	fs.IntVar(&ins.Confirmations, "confirmations", ins.Confirmations, "blocks to wait for each receipt, 0 doesn't wait")
+	fs.IntVar(&ins.ReceiptTimeout, "receipt-timeout", ins.ReceiptTimeout, "the longest time to wait for the receipts, second")
+	fs.IntVar(&ins.StuckTimeout, "stuck-timeout", ins.StuckTimeout, "speed up the txs pending longer than it while waiting for the receipts, second, 0 never")
+	fs.IntVar(&ins.ReplaceBump, "bump", ins.ReplaceBump, "fee increase of the speed up tx, percent")
+	fs.BoolVar(&ins.SkipPreflight, "skip-preflight", ins.SkipPreflight, "start without simulating the txs and checking the balances against their worst case cost")
+	fs.StringVar(&ins.Journal, "journal", ins.Journal, "jsonl file recording the item of every tx for resume, empty records nothing")
+}
+
+func runDrop(args []string) (err error) {
+	ins, err := parseConfig("drop", args, dropFlags)
+	if err != nil {
+		return
+	}
+	if ins.RpcUrl == "" || ins.Manifest == "" {
+		return errors.New("rpc and manifest are required")
+	}
+	account, items, err := loadDrop(ins)
+	if err != nil {
+		return
+	}
+
+	evmApp, err := newApp(ins)
+	if err != nil {
+		return err
+	}
+
+	var j *journal.Journal
+	if ins.Journal != "" {
+		if j, err = journal.Open(ins.Journal); err != nil {
+			return
+		}
+		defer j.Close()
+		if err = j.StartRun(ins); err != nil {
+			return
+		}
+		if err = j.Job(account.Address, len(items)); err != nil {
+			return
+		}
+		app.LogInfof("journal: %s", ins.Journal)
+	}
+	return drop(evmApp, ins, account, items, j)
+}
+
+// loadDrop
+//
+//	@Description: the account of the drop and its items, every payload is built and checked before anything is sent
+//	@param ins
+//	@return *core.Account
+//	@return []*app.DropItem
+//	@return error
+func loadDrop(ins *config.Inscription) (*core.Account, []*app.DropItem, error) {
+	if err := ins.Fee.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if missing := ins.Fee.Missing(); len(missing) > 0 {
+		return nil, nil, fmt.Errorf("missing required config: %s", strings.Join(missing, ", "))
+	}
+	if !ins.HasAccount() {
+		ins.PrivateKey, _ = readSecret("please input privateKey:")
+	}
+	account, err := loadAccount(ins)
+	if err != nil {
+		return nil, nil, err
+	}
+	manifest, err := config.LoadManifest(ins.Manifest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := make([]*app.DropItem, 0, len(manifest))
+	for i, entry := range manifest {
+		item, err := dropItem(ins, account, entry)
+		if err != nil {
+			return nil, nil, fmt.Errorf("item %s: %w", entry.Name, err)
+		}
+		item.Index = i + 1
+		items = append(items, item)
+	}
+	return account, items, nil
+}
+
+// dropItem builds the data, the recipient and the fee of the manifest item
+func dropItem(ins *config.Inscription, account *core.Account, entry *config.Item) (*app.DropItem, error) {
+	text := entry.Text
+	if entry.File != "" {
+		var err error
+		if text, err = ethscription.File(entry.File); err != nil {
+			return nil, err
+		}
+	}
+	if strings.HasPrefix(text, ethscription.Prefix) {
+		if err := ethscription.Validate(text); err != nil {
+			return nil, fmt.Errorf("invalid data URI: %w", err)
+		}
+	}
+	if len(text) > ethscription.MaxDataSize {
+		return nil, fmt.Errorf("the data has %d bytes, more than the %d bytes a tx can carry", len(text), ethscription.MaxDataSize)
+	}
+
+	to := entry.To
+	if to == "" {
+		to = account.Address
+	}
+	if err := util.ValidateAddress(to); err != nil {
+		return nil, err
+	}
+
+	fee := &ins.Fee
+	if entry.GasPrice != "" {
+		fee = &config.Fee{FeeMode: config.FeeModeLegacy, GasPrice: entry.GasPrice}
+		if entry.MaxPriorityFeePerGas != "" {
+			fee.FeeMode = config.FeeModeDynamic
+			fee.MaxPriorityFeePerGas = entry.MaxPriorityFeePerGas
+		}
+	} else if entry.MaxPriorityFeePerGas != "" {
+		return nil, errors.New("maxPriorityFeePerGas needs a gasPrice")
+	}
+
+	return &app.DropItem{
+		Name:     entry.Name,
+		Data:     util.TextToHex(text),
+		To:       to,
+		GasLimit: ins.GasLimit,
+		Fee:      fee,
+	}, nil
+}
+
+// drop
+//
+//	@Description: send the items, wait for the receipts and print the hash and status of every item
+//	@param evmApp
+//	@param ins
+//	@param account
+//	@param items
+//	@param j journal of the run, nil records nothing
+//	@return err
+func drop(evmApp *app.App, ins *config.Inscription, account *core.Account, items []*app.DropItem, j *journal.Journal) (err error) {
+	app.LogInfof("============executing============")
+	app.LogInfof("rpcUrl: %s", ins.RpcUrl)
+	app.LogInfof("account: %s", account.Address)
+	app.LogInfof("the number of items: %d", len(items))
+	logFee(&ins.Fee)
+	if ins.GasLimit != "" {
+		app.LogInfof("gas limit: %s\n\n", ins.GasLimit)
+	}
+
+	evmApp.SetRateLimit(ins.RateLimit)
+	if err = preflight(evmApp, ins, []preflightCheck{{account: account.Address, txs: app.DropPreflight(items)}}); err != nil {
+		return
+	}
+	evmApp.SetJournal(j)
+	tracker := evmApp.NewReceiptTracker(ins.Confirmations, ins.ReceiptTimeout)
+	speedUpStuck(evmApp, tracker, ins, map[string]*core.Account{account.Address: account}, j)
+
+	ctx, stop := interruptContext()
+	defer stop()
+	hashes, failed := evmApp.Drop(ctx, account, items, ins.Delay, tracker)
+
+	receipts := tracker.Receipts()
+	if ins.Confirmations > 0 {
+		app.LogInfof("waiting for %d confirmations of the receipts", ins.Confirmations)
+		receipts = tracker.Wait(ctx)
+	}
+	app.PrintItems(items, hashes, receipts)
+	if j != nil {
+		if err = j.Receipts(receipts); err != nil {
+			app.LogErrorf("write the journal failed,reason: %s", err)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d items are not sent", failed)
+	}
+	return nil
+}
+
+// resumeDrop
+//
+//	@Description: send the items of the last drop of the journal which have no confirmed or pending tx
+//	@param ins config of the drop
+//	@param entries every entry of the journal
+//	@return err
+func resumeDrop(ins *config.Inscription, entries []*journal.Entry) (err error) {
+	account, items, err := loadDrop(ins)
+	if err != nil {
+		return
+	}
+	evmApp, err := newApp(ins)
+	if err != nil {
+		return err
+	}
+	_, runEntries, err := journal.LastRun(entries)
+	if err != nil {
+		return
+	}
+	receipts, err := evmApp.Reconcile(runEntries)
+	if err != nil {
+		return
+	}
+
+	remaining, hashes := remainingItems(items, runEntries, receipts)
+	app.PrintItems(items, hashes, receipts)
+	app.LogInfof("%d of %d items are sent, %d left", len(items)-len(remaining), len(items), len(remaining))
+	if len(remaining) == 0 {
+		return nil
+	}
+
+	j, err := journal.Open(ins.Journal)
+	if err != nil {
+		return
+	}
+	defer j.Close()
+	if err = j.Resume(); err != nil {
+		return
+	}
+	return drop(evmApp, ins, account, remaining, j)
+}
+
+// remainingItems
+//
+//	@Description: the items of the drop without a confirmed or pending tx in the run
+//	@param items
+//	@param runEntries
+//	@param receipts the reconciled receipts of the run
+//	@return remaining
+//	@return hashes the hash of each sent item by name
+func remainingItems(items []*app.DropItem, runEntries []*journal.Entry, receipts []*core.TxReceipt) (remaining []*app.DropItem, hashes map[string]string) {
+	itemOf := make(map[string]string)
+	for _, entry := range runEntries {
+		if entry.Kind == journal.KindTx && entry.Item != "" && entry.Hash != "" {
+			itemOf[entry.Hash] = entry.Item
+		}
+	}
+	// a pending tx may still be mined, its item is counted as sent to never send it twice
+	hashes = make(map[string]string)
+	for _, receipt := range receipts {
+		if receipt.Status != core.TxConfirmed && receipt.Status != core.TxPending {
+			continue
+		}
+		for _, hash := range receipt.Hashes() {
+			if item, ok := itemOf[hash]; ok {
+				hashes[item] = hash
+			}
+		}
+	}
+
+	remaining = make([]*app.DropItem, 0, len(items))
+	for _, item := range items {
+		if _, ok := hashes[item.Name]; !ok {
+			remaining = append(remaining, item)
+		}
+	}
+	return remaining, hashes
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"inscription/app"
+	"inscription/chain/eth/core"
+	"inscription/journal"
+	"reflect"
+	"testing"
+)
+
+func TestRemainingItems(t *testing.T) {
+	var items []*app.DropItem
+	for i, name := range []string{"a.png", "b.png", "c.png", "d.png", "e.png", "f.png"} {
+		items = append(items, &app.DropItem{Index: i + 1, Name: name})
+	}
+	tx := func(item string, nonce uint64, hash string, status string) *journal.Entry {
+		return &journal.Entry{Kind: journal.KindTx, Account: "0xa", Item: item, Nonce: nonce, Hash: hash, Status: status}
+	}
+	// f.png was never sent
+	entries := []*journal.Entry{
+		{Kind: journal.KindJob, Account: "0xa", Times: 6},
+		tx("a.png", 0, "0x1", journal.StatusSent),
+		tx("b.png", 1, "0x2", journal.StatusSent),
+		tx("c.png", 2, "0x3", journal.StatusSent),
+		tx("d.png", 3, "", journal.StatusFailed),
+		tx("d.png", 3, "0x4", journal.StatusSent),
+		tx("e.png", 4, "0x5", journal.StatusSent),
+		// the speed up of e.png has no item
+		tx("", 4, "0x5b", journal.StatusSent),
+	}
+	receipts := []*core.TxReceipt{
+		{From: "0xa", Nonce: 0, Hash: "0x1", Status: core.TxConfirmed},
+		{From: "0xa", Nonce: 1, Hash: "0x2", Status: core.TxReverted},
+		{From: "0xa", Nonce: 2, Hash: "0x3", Status: core.TxDropped},
+		{From: "0xa", Nonce: 3, Hash: "0x4", Status: core.TxPending},
+		{From: "0xa", Nonce: 4, Hash: "0x5b", Replaced: []string{"0x5"}, Status: core.TxConfirmed},
+	}
+
+	remaining, hashes := remainingItems(items, entries, receipts)
+	var names []string
+	for _, item := range remaining {
+		names = append(names, item.Name)
+	}
+	if want := []string{"b.png", "c.png", "f.png"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("%v left, want %v", names, want)
+	}
+	if want := map[string]string{"a.png": "0x1", "d.png": "0x4", "e.png": "0x5"}; !reflect.DeepEqual(hashes, want) {
+		t.Fatalf("sent %v, want %v", hashes, want)
+	}
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"flag"
+	"github.com/shopspring/decimal"
+	"inscription/app"
+	"inscription/config"
+)
+
+// feeFlags
+//
+//	@Description: register the fee flags, the current values of fee are used as defaults
+//	@param fs
+//	@param fee
+func feeFlags(fs *flag.FlagSet, fee *config.Fee) {
+	fs.StringVar(&fee.FeeMode, "fee-mode", fee.FeeMode, "legacy, 1559 or auto")
+	fs.StringVar(&fee.GasPrice, "gas-price", fee.GasPrice, "gas price of legacy tx or max fee per gas of 1559 tx, wei")
+	fs.StringVar(&fee.MaxPriorityFeePerGas, "max-priority-fee", fee.MaxPriorityFeePerGas, "max priority fee per gas of 1559 tx, wei")
+	fs.Float64Var(&fee.FeeMultiplier, "fee-multiplier", fee.FeeMultiplier, "auto mode, max fee = base fee * multiplier + tip")
+	fs.StringVar(&fee.MaxFeeCap, "max-fee-cap", fee.MaxFeeCap, "auto mode, upper bound of the max fee per gas, wei")
+	fs.StringVar(&fee.PriorityFeeCap, "priority-fee-cap", fee.PriorityFeeCap, "auto mode, upper bound of the max priority fee per gas, wei")
+}
+
+// logFee
+//
+//	@Description: print the fee settings in gwei
+//	@param fee
+func logFee(fee *config.Fee) {
+	switch fee.FeeMode {
+	case config.FeeModeDynamic:
+		app.LogInfof("fee mode: %s, max fee: %sGwei, max priority fee: %sGwei", fee.FeeMode, toGwei(fee.GasPrice), toGwei(fee.MaxPriorityFeePerGas))
+	case config.FeeModeAuto:
+		app.LogInfof("fee mode: %s, base fee multiplier: %v", fee.FeeMode, fee.FeeMultiplier)
+	default:
+		app.LogInfof("gas price: %sGwei", toGwei(fee.GasPrice))
+	}
+}
+
+func toGwei(wei string) string {
+	value, err := decimal.NewFromString(wei)
+	if err != nil {
+		return wei
+	}
+	return value.DivRound(decimal.New(1, 9), 18).String()
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"inscription/app"
+	"inscription/chain/util"
+	"inscription/config"
+	"math/big"
+	"os"
+	"text/tabwriter"
+)
+
+// fundFlags are the flags of distribute and sweep besides the config
+type fundFlags struct {
+	to     string
+	toFile string
+	amount string
+	topUp  bool
+	dryRun bool
+}
+
+func runDistribute(args []string) error {
+	var f fundFlags
+	ins, err := parseConfig("distribute", args, func(fs *flag.FlagSet, ins *config.Inscription) {
+		keystoreFlags(fs, ins)
+		feeFlags(fs, &ins.Fee)
+		fs.StringVar(&ins.KeyFile, "key-file", ins.KeyFile, "receive with the wallets of the key file")
+		mnemonicFlags(fs, ins)
+		fs.StringVar(&f.toFile, "to-file", "", "receive with the addresses of the file (.json, .csv or one per line)")
+		fs.StringVar(&f.amount, "amount", "", "eth sent to each wallet")
+		fs.BoolVar(&f.topUp, "top-up", false, "only send what each wallet lacks to hold the amount")
+		fs.BoolVar(&f.dryRun, "dry-run", false, "print the transfers without sending them")
+		fs.IntVar(&ins.Confirmations, "confirmations", ins.Confirmations, "blocks to wait for each receipt, 0 doesn't wait")
+		fs.IntVar(&ins.ReceiptTimeout, "receipt-timeout", ins.ReceiptTimeout, "the longest time to wait for the receipts, second")
+	})
+	if err != nil {
+		return err
+	}
+	amount, err := toWei(f.amount)
+	if err != nil {
+		return err
+	}
+
+	// the funding wallet is the key or keystore, the receivers are the key file, the mnemonic or the address file
+	funder, err := loadAccount(&config.Inscription{PrivateKey: ins.PrivateKey, Keystore: ins.Keystore, PassphraseFile: ins.PassphraseFile})
+	if err != nil {
+		return fmt.Errorf("funding wallet: %w", err)
+	}
+	receivers, err := fundReceivers(ins, f.toFile)
+	if err != nil {
+		return err
+	}
+
+	evmApp, err := newFundApp(ins)
+	if err != nil {
+		return err
+	}
+	plans, err := evmApp.PlanDistribute(funder, receivers, amount, f.topUp, &ins.Fee)
+	printPlans(plans)
+	if err != nil || f.dryRun {
+		return err
+	}
+	return executePlans(evmApp, plans, ins)
+}
+
+func runSweep(args []string) error {
+	var f fundFlags
+	ins, err := parseConfig("sweep", args, func(fs *flag.FlagSet, ins *config.Inscription) {
+		keystoreFlags(fs, ins)
+		feeFlags(fs, &ins.Fee)
+		fs.StringVar(&ins.KeyFile, "key-file", ins.KeyFile, "sweep the wallets of the key file")
+		mnemonicFlags(fs, ins)
+		fs.StringVar(&f.to, "to", "", "address receiving the leftovers")
+		fs.BoolVar(&f.dryRun, "dry-run", false, "print the transfers without sending them")
+		fs.IntVar(&ins.Confirmations, "confirmations", ins.Confirmations, "blocks to wait for each receipt, 0 doesn't wait")
+		fs.IntVar(&ins.ReceiptTimeout, "receipt-timeout", ins.ReceiptTimeout, "the longest time to wait for the receipts, second")
+	})
+	if err != nil {
+		return err
+	}
+	if err = util.ValidateAddress(f.to); err != nil {
+		return err
+	}
+
+	wallets, err := loadWallets(ins)
+	if err != nil {
+		return err
+	}
+	evmApp, err := newFundApp(ins)
+	if err != nil {
+		return err
+	}
+	if ins.FeeMode != config.FeeModeLegacy {
+		app.LogInfof("the unused part of the max fee stays in the wallets, sweep with the legacy fee mode to empty them")
+	}
+
+	plans, err := evmApp.PlanSweep(walletAccounts(wallets), f.to, &ins.Fee)
+	if err != nil {
+		return err
+	}
+	printPlans(plans)
+	if f.dryRun {
+		return nil
+	}
+	return executePlans(evmApp, plans, ins)
+}
+
+// fundReceivers returns the addresses of the address file or of the wallets
+func fundReceivers(ins *config.Inscription, toFile string) ([]string, error) {
+	if toFile != "" {
+		addresses, err := config.LoadAddresses(toFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, address := range addresses {
+			if err := util.ValidateAddress(address); err != nil {
+				return nil, fmt.Errorf("%s: %w", toFile, err)
+			}
+		}
+		return addresses, nil
+	}
+	if ins.KeyFile == "" && ins.Mnemonic == "" {
+		return nil, errors.New("no receiver, set key-file, mnemonic or to-file")
+	}
+
+	wallets, err := loadWallets(&config.Inscription{
+		KeyFile:            ins.KeyFile,
+		Mnemonic:           ins.Mnemonic,
+		MnemonicPassphrase: ins.MnemonicPassphrase,
+		DerivationPath:     ins.DerivationPath,
+		AccountStart:       ins.AccountStart,
+		AccountCount:       ins.AccountCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]string, 0, len(wallets))
+	for _, account := range walletAccounts(wallets) {
+		addresses = append(addresses, account.Address)
+	}
+	return addresses, nil
+}
+
+func newFundApp(ins *config.Inscription) (*app.App, error) {
+	if err := ins.Fee.Validate(); err != nil {
+		return nil, err
+	}
+	if ins.RpcUrl == "" {
+		return nil, errors.New("rpc is required")
+	}
+	evmApp, err := newApp(ins)
+	if err != nil {
+		return nil, err
+	}
+	return evmApp, nil
+}
+
+func executePlans(evmApp *app.App, plans []*app.TransferPlan, ins *config.Inscription) error {
+	ctx, stop := interruptContext()
+	defer stop()
+
+	tracker := evmApp.NewReceiptTracker(ins.Confirmations, ins.ReceiptTimeout)
+	failed := evmApp.ExecuteTransfers(ctx, plans, tracker)
+	if ins.Confirmations > 0 {
+		app.PrintReceipts(tracker.Wait(ctx))
+	} else {
+		app.PrintReceipts(tracker.Receipts())
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d transfers are not sent", failed)
+	}
+	return nil
+}
+
+func printPlans(plans []*app.TransferPlan) {
+	if len(plans) == 0 {
+		return
+	}
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tFROM\tTO\tVALUE(ETH)\tMAX GAS COST(ETH)")
+	for i, plan := range plans {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", i+1, plan.From.Address, plan.To, fromWei(plan.Value), fromWei(plan.GasCost()))
+	}
+	w.Flush()
+	os.Stdout.Write(buf.Bytes())
+}
+
+func toWei(eth string) (*big.Int, error) {
+	amount, err := decimal.NewFromString(eth)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %q", eth)
+	}
+	if amount.Sign() <= 0 {
+		return nil, fmt.Errorf("amount %s should be positive", eth)
+	}
+	return amount.Shift(18).BigInt(), nil
+}
+
+func fromWei(wei *big.Int) string {
+	return decimal.NewFromBigInt(wei, -18).String()
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"inscription/app"
+	"inscription/chain/eth/core"
+	"inscription/config"
+)
+
+// keystoreFlags
+//
+//	@Description: flags to sign with encrypted keystore files
+//	@param fs
+//	@param ins
+func keystoreFlags(fs *flag.FlagSet, ins *config.Inscription) {
+	fs.StringVar(&ins.Keystore, "keystore", ins.Keystore, "encrypted keystore file or directory, replaces -key and -key-file")
+	fs.StringVar(&ins.PassphraseFile, "passphrase-file", ins.PassphraseFile, "file of the keystore passphrase, otherwise $"+config.PassphraseEnv+" or the terminal")
+}
+
+func runImport(args []string) error {
+	var dir, key string
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.StringVar(&dir, "dir", "keystore", "directory of the new keystore file")
+	fs.StringVar(&key, "key", "", "private key or mnemonic to import, asked without echo when empty")
+	passphraseFile := fs.String("passphrase-file", "", "file of the new passphrase, otherwise $"+config.PassphraseEnv+" or the terminal")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if key == "" {
+		var err error
+		if key, err = readSecret("please input the private key or mnemonic:"); err != nil {
+			return err
+		}
+	}
+	if key == "" {
+		return errors.New("private key or mnemonic is required")
+	}
+	account, err := core.NewAccount().AccountWithKey(key)
+	if err != nil {
+		return err
+	}
+	privateKeyECDSA, err := core.PrivateKeyToECDSA(account.PrivateKey)
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := readPassphrase(*passphraseFile, true)
+	if err != nil {
+		return err
+	}
+	path, err := core.ImportKeystore(dir, privateKeyECDSA, passphrase)
+	if err != nil {
+		return err
+	}
+	app.LogInfof("imported %s into %s", account.Address, path)
+	return nil
+}
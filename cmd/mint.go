@@ -0,0 +1,466 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"inscription/app"
+	"inscription/chain/eth/core"
+	"inscription/chain/util"
+	"inscription/config"
+	"inscription/ethscription"
+	"inscription/journal"
+	"inscription/payload"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// payloadPreview is the number of payloads rendered before the run starts
+const payloadPreview = 3
+
+// mintFlags
+//
+//	@Description: flags of the mint command
+//	@param fs
+//	@param ins
+func mintFlags(fs *flag.FlagSet, ins *config.Inscription) {
+	fs.StringVar(&ins.KeyFile, "key-file", ins.KeyFile, "file of private keys or mnemonics (.json, .csv or one per line) to mint with several wallets")
+	keystoreFlags(fs, ins)
+	mnemonicFlags(fs, ins)
+	fs.StringVar(&ins.Data, "data", ins.Data, "inscription data in hex")
+	fs.StringVar(&ins.Text, "text", ins.Text, "inscription original text, used when -data is empty")
+	fs.StringVar(&ins.File, "file", ins.File, "file inscribed as a base64 data URI (png, svg, gif, webp, html, json...), used when -data and -text are empty")
+	operationFlags(fs, &ins.Operation)
+	contractFlags(fs, ins)
+	fs.StringVar(&ins.Json, "json", ins.Json, "json document inscribed as a data:application/json URI, used when -data, -text, -file and -op are empty")
+	feeFlags(fs, &ins.Fee)
+	fs.StringVar(&ins.GasLimit, "gas-limit", ins.GasLimit, "gas limit, estimated for a file or a contract call when empty")
+	fs.StringVar(&ins.To, "to", ins.To, "recipient of every inscription, empty is the wallet itself")
+	fs.StringVar(&ins.ToFile, "to-file", ins.ToFile, "file of recipients (.json, .csv or one per line), each wallet sends one inscription to every recipient")
+	fs.IntVar(&ins.Times, "times", ins.Times, "the number of inscriptions")
+	fs.IntVar(&ins.Delay, "delay", ins.Delay, "the time interval for each inscription, second")
+	fs.IntVar(&ins.Workers, "workers", ins.Workers, "wallets minting at the same time")
+	fs.IntVar(&ins.Pipeline, "pipeline", ins.Pipeline, "txs of a wallet signed ahead at the same time, they are sent in nonce order")
+	fs.Float64Var(&ins.RateLimit, "rate-limit", ins.RateLimit, "requests per second sent to each rpc node, 0 is unlimited")
+	fs.IntVar(&ins.Confirmations, "confirmations", ins.Confirmations, "blocks to wait for each receipt, 0 doesn't wait")
+	fs.IntVar(&ins.ReceiptTimeout, "receipt-timeout", ins.ReceiptTimeout, "the longest time to wait for the receipts, second")
+	fs.IntVar(&ins.StuckTimeout, "stuck-timeout", ins.StuckTimeout, "speed up the txs pending longer than it while waiting for the receipts, second, 0 never")
+	fs.IntVar(&ins.ReplaceBump, "bump", ins.ReplaceBump, "fee increase of the speed up tx, percent")
+	scheduleFlags(fs, &ins.Schedule)
+	fs.BoolVar(&ins.SkipPreflight, "skip-preflight", ins.SkipPreflight, "start without simulating the txs and checking the balances against their worst case cost")
+	fs.StringVar(&ins.Journal, "journal", ins.Journal, "jsonl file recording the txs of the run for resume, empty records nothing")
+}
+
+// loadMintConfig
+//
+//	@Description: parse the config and prompt for whatever required is still missing
+//	@param args
+//	@return *config.Inscription
+//	@return error
+func loadMintConfig(args []string) (*config.Inscription, error) {
+	ins, err := parseConfig("mint", args, mintFlags)
+	if err != nil {
+		return nil, err
+	}
+	return ins, requireConfig(ins)
+}
+
+// requireConfig prompts for whatever required is missing
+func requireConfig(ins *config.Inscription) error {
+	if missing := ins.Missing(); len(missing) > 0 {
+		app.LogInfof("missing %s, please input them", strings.Join(missing, ", "))
+		if err := promptMissing(ins); err != nil {
+			return err
+		}
+		if missing = ins.Missing(); len(missing) > 0 {
+			return fmt.Errorf("missing required config: %s", strings.Join(missing, ", "))
+		}
+	}
+	return nil
+}
+
+func runMint(args []string) error {
+	ins, err := loadMintConfig(args)
+	if err != nil {
+		return err
+	}
+	return mint(ins)
+}
+
+func runInteractiveMint() error {
+	ins := config.NewInscription()
+	if err := promptMissing(ins); err != nil {
+		return err
+	}
+	promptTimes(ins)
+	return mint(ins)
+}
+
+func mint(mintConfig *config.Inscription) (err error) {
+	if err = checkMintConfig(mintConfig); err != nil {
+		return
+	}
+	jobs, err := mintJobs(mintConfig)
+	if err != nil {
+		return
+	}
+
+	evmApp, err := newApp(mintConfig)
+	if err != nil {
+		return err
+	}
+	if err = prepareGasLimit(evmApp, mintConfig, jobs); err != nil {
+		return
+	}
+
+	var j *journal.Journal
+	if mintConfig.Journal != "" {
+		if j, err = journal.Open(mintConfig.Journal); err != nil {
+			return
+		}
+		defer j.Close()
+		if err = j.StartRun(mintConfig); err != nil {
+			return
+		}
+		for _, job := range jobs {
+			if err = j.Job(job.Account.Address, job.Times); err != nil {
+				return
+			}
+		}
+		app.LogInfof("journal: %s", mintConfig.Journal)
+	}
+	return runJobs(evmApp, mintConfig, jobs, j)
+}
+
+// prepareGasLimit
+//
+//	@Description: estimate the gas limit of a file inscription or simulate the contract call with the first wallet,
+//	then give the gas limit to every job
+//	@param evmApp
+//	@param mintConfig
+//	@param jobs
+//	@return error
+func prepareGasLimit(evmApp *app.App, mintConfig *config.Inscription, jobs []*app.MintJob) (err error) {
+	switch {
+	case mintConfig.Contract != "":
+		err = simulateCall(evmApp, mintConfig, jobs[0].Account.Address)
+	case mintConfig.File != "":
+		err = fileGasLimit(evmApp, mintConfig, jobs[0].Account.Address)
+	}
+	if err != nil {
+		return
+	}
+	for _, job := range jobs {
+		job.GasLimit = mintConfig.GasLimit
+	}
+	return nil
+}
+
+// checkMintConfig validates the fee, builds the hex data from the payload when there is none and validates its data URI
+func checkMintConfig(mintConfig *config.Inscription) error {
+	if err := mintConfig.Fee.Validate(); err != nil {
+		return err
+	}
+	if err := checkSchedule(mintConfig); err != nil {
+		return err
+	}
+	if mintConfig.Contract != "" {
+		return checkContractCall(mintConfig)
+	}
+	if mintConfig.Data == "" {
+		text, err := payloadText(mintConfig)
+		if err != nil {
+			return err
+		}
+		mintConfig.Data = util.TextToHex(text)
+	}
+	if size := (len(mintConfig.Data) - 2) / 2; size > ethscription.MaxDataSize {
+		return fmt.Errorf("the data has %d bytes, more than the %d bytes a tx can carry", size, ethscription.MaxDataSize)
+	}
+	if text, ok := payloadTemplate(mintConfig.Data); ok {
+		app.LogInfof("payload template: %s", text)
+		return nil
+	}
+	if uri, ok := dataURI(mintConfig.Data); ok {
+		if err := ethscription.Validate(uri); err != nil {
+			return fmt.Errorf("invalid data URI: %w", err)
+		}
+		app.LogInfof("ethscription sha256: %s", ethscription.SHA256(uri))
+	}
+	return nil
+}
+
+// fileGasLimit
+//
+//	@Description: check the gas limit of the file inscription against the gas of its calldata and the estimate of the node,
+//	the estimate is taken when there is no gas limit
+//	@param evmApp
+//	@param mintConfig
+//	@param from
+//	@return error
+func fileGasLimit(evmApp *app.App, mintConfig *config.Inscription, from string) error {
+	data, err := util.HexDecodeString(mintConfig.Data)
+	if err != nil {
+		return err
+	}
+	intrinsic := ethscription.IntrinsicGas(data)
+	estimate, err := evmApp.EstimateGasLimit(from, from, "0", mintConfig.Data)
+	if err != nil {
+		return fmt.Errorf("estimate the gas of %s failed: %w", mintConfig.File, err)
+	}
+	app.LogInfof("%s has %d bytes of data, the calldata gas is %d, the estimated gas limit is %s", mintConfig.File, len(data), intrinsic, estimate)
+
+	if mintConfig.GasLimit == "" {
+		mintConfig.GasLimit = estimate
+		return nil
+	}
+	limit, err := strconv.ParseUint(mintConfig.GasLimit, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid gas limit %s", mintConfig.GasLimit)
+	}
+	if limit < intrinsic {
+		return fmt.Errorf("gas limit %d is below the %d gas of the calldata", limit, intrinsic)
+	}
+	if estimated, _ := strconv.ParseUint(estimate, 10, 64); limit < estimated {
+		app.LogInfof("gas limit %d is below the estimate %d", limit, estimated)
+	}
+	return nil
+}
+
+// payloadText is the original text of the inscription
+func payloadText(mintConfig *config.Inscription) (string, error) {
+	switch {
+	case mintConfig.Text != "":
+		return mintConfig.Text, nil
+	case mintConfig.File != "":
+		return ethscription.File(mintConfig.File)
+	case mintConfig.Operation.Op != "":
+		return mintConfig.Operation.URI()
+	case mintConfig.Json != "":
+		return ethscription.JSON([]byte(mintConfig.Json))
+	}
+	return "", nil
+}
+
+// payloadTemplate decodes the hex data, ok is false when it isn't a payload template
+func payloadTemplate(data string) (text string, ok bool) {
+	raw, err := util.HexDecodeString(data)
+	if err != nil || !payload.IsTemplate(string(raw)) {
+		return "", false
+	}
+	return string(raw), true
+}
+
+// previewPayload
+//
+//	@Description: render the first payloads of the first job, a template which can't be rendered stops the run
+//	@param jobs
+//	@return error
+func previewPayload(jobs []*app.MintJob) error {
+	job := jobs[0]
+	for k := 1; k <= payloadPreview && k <= job.Times; k++ {
+		text, err := job.Payload.Render(payload.Vars{
+			Index:     job.Index(k - 1),
+			Counter:   k,
+			Address:   job.Account.Address,
+			Timestamp: time.Now().Unix(),
+		})
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(text, ethscription.Prefix) {
+			if err = ethscription.Validate(text); err != nil {
+				return fmt.Errorf("invalid data URI %s: %w", text, err)
+			}
+		}
+		app.LogInfof("payload %d of %s: %s", job.Index(k-1), job.Account.Address, text)
+	}
+	return nil
+}
+
+// dataURI decodes the hex data, ok is false when it isn't a data URI
+func dataURI(data string) (uri string, ok bool) {
+	raw, err := util.HexDecodeString(data)
+	if err != nil || !strings.HasPrefix(string(raw), ethscription.Prefix) {
+		return "", false
+	}
+	return string(raw), true
+}
+
+// runJobs
+//
+//	@Description: mint the jobs, wait for the receipts and print the summary
+//	@param evmApp
+//	@param mintConfig
+//	@param jobs
+//	@param j journal of the run, nil records nothing
+//	@return err
+func runJobs(evmApp *app.App, mintConfig *config.Inscription, jobs []*app.MintJob, j *journal.Journal) (err error) {
+	app.LogInfof("============executing============")
+	app.LogInfof("rpcUrl: %s", mintConfig.RpcUrl)
+	app.LogInfof("the number of wallets: %d", len(jobs))
+	total := 0
+	for _, job := range jobs {
+		total += job.Times
+	}
+	app.LogInfof("the number of inscriptions: %d", total)
+	if jobs[0].Payload != nil {
+		if err = previewPayload(jobs); err != nil {
+			return
+		}
+	} else if _, ok := dataURI(mintConfig.Data); ok && total > 1 {
+		app.LogInfof("the inscriptions share one data URI, only the first one mined counts as an ethscription")
+	}
+	logFee(&mintConfig.Fee)
+	app.LogInfof("gas limit: %s\n\n", mintConfig.GasLimit)
+
+	evmApp.SetRateLimit(mintConfig.RateLimit)
+	if err = mintPreflight(evmApp, mintConfig, jobs); err != nil {
+		return
+	}
+	schedule, err := mintSchedule(evmApp, mintConfig)
+	if err != nil {
+		return
+	}
+	evmApp.SetJournal(j)
+	tracker := evmApp.NewReceiptTracker(mintConfig.Confirmations, mintConfig.ReceiptTimeout)
+	speedUpStuck(evmApp, tracker, mintConfig, jobAccounts(jobs), j)
+
+	ctx, stop := interruptContext()
+	defer stop()
+	if mintConfig.HasStart() {
+		for _, job := range jobs {
+			if err = evmApp.PreSign(job, mintConfig.PreSign); err != nil {
+				break
+			}
+		}
+		if err == nil && evmApp.WaitStart(ctx, schedule) != nil {
+			err = errors.New("interrupted before the start, nothing is sent")
+		}
+		if err != nil {
+			for _, job := range jobs {
+				evmApp.DiscardSigned(job)
+			}
+			return
+		}
+	}
+	mintCtx, cancel := evmApp.StopWhen(ctx, schedule)
+	defer cancel()
+	//begin
+	failed := evmApp.NewEngine(mintConfig.Workers, mintConfig.Pipeline).Run(mintCtx, jobs, tracker)
+	stopped := mintCtx.Err() != nil && ctx.Err() == nil
+	cancel()
+
+	receipts := tracker.Receipts()
+	if mintConfig.Confirmations > 0 {
+		// once interrupted the receipts are only queried once
+		app.LogInfof("waiting for %d confirmations of the receipts", mintConfig.Confirmations)
+		receipts = tracker.Wait(ctx)
+	}
+	app.PrintReceipts(receipts)
+	if j != nil {
+		if err = j.Receipts(receipts); err != nil {
+			app.LogErrorf("write the journal failed,reason: %s", err)
+		}
+	}
+	if failed > 0 && stopped {
+		return fmt.Errorf("%d inscriptions are not sent, the mint is stopped by the end block or the max supply", failed)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d inscriptions are not sent", failed)
+	}
+	return nil
+}
+
+// mintJobs
+//
+//	@Description: one job for each wallet of the run
+//	@param mintConfig
+//	@return []*app.MintJob
+//	@return error
+func mintJobs(mintConfig *config.Inscription) ([]*app.MintJob, error) {
+	wallets, err := loadWallets(mintConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients, err := mintRecipients(mintConfig)
+	if err != nil {
+		return nil, err
+	}
+	value := ""
+	if mintConfig.Contract != "" {
+		recipients = []string{mintConfig.Contract}
+		value = callValue(mintConfig)
+	}
+
+	var tmpl *payload.Template
+	if text, ok := payloadTemplate(mintConfig.Data); ok {
+		if tmpl, err = payload.Parse(text); err != nil {
+			return nil, err
+		}
+	}
+
+	jobs := make([]*app.MintJob, 0, len(wallets))
+	for _, wallet := range wallets {
+		job := &app.MintJob{
+			Account:    wallet.account,
+			Data:       mintConfig.Data,
+			Payload:    tmpl,
+			GasLimit:   mintConfig.GasLimit,
+			Fee:        &mintConfig.Fee,
+			Times:      wallet.times,
+			Delay:      wallet.delay,
+			Recipients: recipients,
+			Value:      value,
+		}
+		if mintConfig.ToFile != "" {
+			job.Times = len(recipients)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// mintRecipients
+//
+//	@Description: the recipients of the recipient file or the recipient, none means the wallets themselves
+//	@param mintConfig
+//	@return []string
+//	@return error
+func mintRecipients(mintConfig *config.Inscription) ([]string, error) {
+	recipients := []string{mintConfig.To}
+	if mintConfig.ToFile != "" {
+		var err error
+		if recipients, err = config.LoadAddresses(mintConfig.ToFile); err != nil {
+			return nil, err
+		}
+		if len(recipients) == 0 {
+			return nil, fmt.Errorf("no recipient in %s", mintConfig.ToFile)
+		}
+		app.LogInfof("every wallet sends %d inscriptions, one to each recipient of %s", len(recipients), mintConfig.ToFile)
+	} else if mintConfig.To == "" {
+		return nil, nil
+	}
+
+	for _, recipient := range recipients {
+		if err := util.ValidateAddress(recipient); err != nil {
+			return nil, err
+		}
+	}
+	return recipients, nil
+}
+
+// jobAccounts
+//
+//	@Description: accounts of the jobs by address
+//	@param jobs
+//	@return map[string]*core.Account
+func jobAccounts(jobs []*app.MintJob) map[string]*core.Account {
+	accounts := make(map[string]*core.Account, len(jobs))
+	for _, job := range jobs {
+		accounts[job.Account.Address] = job.Account
+	}
+	return accounts
+}
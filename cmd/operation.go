@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"inscription/ethscription"
+	"strings"
+)
+
+// operationFlags
+//
+//	@Description: flags of the token operation inscribed instead of a text
+//	@param fs
+//	@param op
+func operationFlags(fs *flag.FlagSet, op *ethscription.Operation) {
+	fs.StringVar(&op.Op, "op", op.Op, "token operation inscribed when -data and -text are empty: deploy, mint or transfer")
+	fs.StringVar(&op.P, "protocol", op.P, "protocol of the token operation: "+strings.Join(ethscription.Protocols, ", "))
+	fs.StringVar(&op.Tick, "tick", op.Tick, "tick of the token")
+	fs.StringVar(&op.Id, "id", op.Id, "optional id of the mint")
+	fs.StringVar(&op.Max, "max", op.Max, "max supply of the deploy")
+	fs.StringVar(&op.Lim, "lim", op.Lim, "optional limit of each mint of the deploy")
+	fs.StringVar(&op.Amt, "amt", op.Amt, "amount of the mint or transfer")
+}
+
+// promptOperation
+//
+//	@Description: ask for the fields of the token operation until they're valid
+//	@param op
+//	@return string the data URI
+//	@return error io.EOF once the input is closed
+func promptOperation(op *ethscription.Operation) (string, error) {
+	for {
+		fmt.Printf("please input protocol: (default %s)\n", ethscription.DefaultProtocol)
+		if err := scanWord(&op.P); err != nil {
+			return "", err
+		}
+		if op.P == "" {
+			op.P = ethscription.DefaultProtocol
+		}
+		fmt.Println("please input tick:")
+		if err := scanWord(&op.Tick); err != nil {
+			return "", err
+		}
+		if op.Op == ethscription.OpDeploy {
+			fmt.Println("please input max supply:")
+			if err := scanWord(&op.Max); err != nil {
+				return "", err
+			}
+			fmt.Println("please input the limit of each mint: (optional)")
+			if err := scanWord(&op.Lim); err != nil {
+				return "", err
+			}
+		} else {
+			fmt.Printf("please input the amount of the %s:\n", op.Op)
+			if err := scanWord(&op.Amt); err != nil {
+				return "", err
+			}
+		}
+
+		uri, err := op.URI()
+		if err == nil {
+			return uri, nil
+		}
+		fmt.Printf("invalid %s: %s\n", op.Op, err)
+		*op = ethscription.Operation{Op: op.Op}
+	}
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"golang.org/x/term"
+	"inscription/app"
+	"inscription/chain/eth/contract"
+	"inscription/config"
+	"os"
+	"text/tabwriter"
+)
+
+// preflightCheck is the txs an account is about to send
+type preflightCheck struct {
+	account string
+	txs     []*app.PreflightTx
+}
+
+// mintPreflight
+//
+//	@Description: check the remaining inscriptions of every job before the run
+//	@param evmApp
+//	@param ins
+//	@param jobs
+//	@return error
+func mintPreflight(evmApp *app.App, ins *config.Inscription, jobs []*app.MintJob) error {
+	checks := make([]preflightCheck, 0, len(jobs))
+	for _, job := range jobs {
+		txs, err := app.MintPreflight(job)
+		if err != nil {
+			return err
+		}
+		checks = append(checks, preflightCheck{account: job.Account.Address, txs: txs})
+	}
+	return preflight(evmApp, ins, checks)
+}
+
+// preflight
+//
+//	@Description: simulate the txs of every account and add up their worst case cost against the balance.
+//	A failed simulation or a gas limit below the estimate refuses to start, a balance short of the cost
+//	asks on a terminal and refuses otherwise.
+//	@param evmApp
+//	@param ins
+//	@param checks
+//	@return error
+func preflight(evmApp *app.App, ins *config.Inscription, checks []preflightCheck) error {
+	if ins.SkipPreflight {
+		app.LogInfof("the preflight check is skipped")
+		return nil
+	}
+	app.LogInfof("preflight check of %d accounts", len(checks))
+
+	// the custom errors of the ABI decode the reverts of a contract call
+	fn := &contract.Function{}
+	if ins.Contract != "" && ins.Method != "" {
+		if called, err := contractFunction(ins); err == nil {
+			fn = called
+		}
+	}
+
+	results := make([]*app.Preflight, 0, len(checks))
+	failed, short := 0, 0
+	for _, check := range checks {
+		result := evmApp.Preflight(check.account, check.txs)
+		results = append(results, result)
+		switch {
+		case result.Err != nil:
+			failed++
+			app.LogErrorf("%s preflight failed,reason: %s", result.Account, revertReason(fn, result.Err))
+		case result.Shortfall() != nil:
+			short++
+		}
+	}
+	printPreflights(results)
+
+	if failed > 0 {
+		return fmt.Errorf("%d accounts fail the preflight check, fix them or skip the check with -skip-preflight", failed)
+	}
+	if short == 0 {
+		return nil
+	}
+	message := fmt.Sprintf("the balances of %d accounts can't cover the worst case cost", short)
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return errors.New(message)
+	}
+	var confirm string
+	fmt.Printf("%s, start anyway? input y/n\n", message)
+	fmt.Scanln(&confirm)
+	if confirm != "y" {
+		return errors.New(message)
+	}
+	return nil
+}
+
+// printPreflights prints a table of the preflight checks
+func printPreflights(results []*app.Preflight) {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tACCOUNT\tTXS\tGAS ESTIMATE\tGAS LIMIT\tMAX COST(ETH)\tBALANCE(ETH)\tSTATUS")
+	for i, result := range results {
+		status := "ok"
+		if result.Err != nil {
+			status = "failed"
+		} else if shortfall := result.Shortfall(); shortfall != nil {
+			status = "short of " + fromWei(shortfall)
+		}
+		balance := "-"
+		if result.Balance != nil {
+			balance = fromWei(result.Balance)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%d\t%s\t%s\t%s\n", i+1, result.Account, result.Txs, result.Estimate, result.GasLimit, fromWei(result.Cost), balance, status)
+	}
+	w.Flush()
+	app.LogInfof("============preflight============\n%s", buf.String())
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"inscription/chain/util"
+	"inscription/config"
+	"inscription/ethscription"
+	"io"
+	"os"
+	"strings"
+)
+
+// errInputClosed stops the prompts once the input is closed, e.g. piped or redirected from a file
+var errInputClosed = fmt.Errorf("the input is closed: %w", io.EOF)
+
+// promptMissing
+//
+//	@Description: ask for the required fields which are neither in the flags nor in the config file
+//	@param ins
+//	@return error io.EOF once the input is closed
+func promptMissing(ins *config.Inscription) error {
+	if !ins.HasAccount() {
+		ins.PrivateKey, _ = readSecret("please input privateKey:")
+	}
+
+	if !ins.HasPayload() && ins.Method == "" {
+		if err := promptData(ins); err != nil {
+			return err
+		}
+	}
+
+	if ins.RpcUrl == "" {
+		fmt.Println("please input rpcUrl:  (free and stable rpc provider in https://zan.top/home/node-service)")
+		fmt.Scanln(&ins.RpcUrl)
+	}
+
+	if ins.GasPrice == "" && ins.FeeMode != config.FeeModeAuto {
+		fmt.Println("please input gasPrice:")
+		fmt.Scanln(&ins.GasPrice)
+	}
+
+	if ins.MaxPriorityFeePerGas == "" && ins.FeeMode == config.FeeModeDynamic {
+		fmt.Println("please input maxPriorityFeePerGas:")
+		fmt.Scanln(&ins.MaxPriorityFeePerGas)
+	}
+
+	if ins.GasLimit == "" && ins.File == "" && ins.Contract == "" {
+		fmt.Println("please input gasLimit:")
+		fmt.Scanln(&ins.GasLimit)
+	}
+	return nil
+}
+
+// promptData
+//
+//	@Description: ask for the inscription and its confirmation
+//	@param ins
+//	@return error io.EOF once the input is closed
+func promptData(ins *config.Inscription) error {
+	var choice string
+	fmt.Println("please choose the inscription: 1-text, 2-deploy, 3-mint, 4-transfer, 5-file")
+	if err := scanWord(&choice); err != nil {
+		return err
+	}
+
+	var text string
+	var err error
+	switch choice {
+	case "2":
+		text, err = promptOperation(&ethscription.Operation{Op: ethscription.OpDeploy})
+	case "3":
+		text, err = promptOperation(&ethscription.Operation{Op: ethscription.OpMint})
+	case "4":
+		text, err = promptOperation(&ethscription.Operation{Op: ethscription.OpTransfer})
+	case "5":
+		for text == "" && err == nil {
+			fmt.Println("please input the file path:")
+			var path string
+			if path, err = readLine(); err != nil {
+				break
+			}
+			uri, fileErr := ethscription.File(path)
+			if fileErr != nil {
+				fmt.Printf("read %s failed: %s\n", path, fileErr)
+				continue
+			}
+			ins.File = path
+			text = uri
+		}
+	default:
+		fmt.Println("please input text:")
+		text, err = readLine()
+	}
+	if err != nil {
+		return err
+	}
+
+	data := util.TextToHex(text)
+	if ins.File != "" {
+		fmt.Printf("please comfirm data: %s (%d bytes)\n", ethscription.DetectMimeType(ins.File, nil), len(text))
+	} else {
+		fmt.Printf("please comfirm data hex: %s\n", data)
+	}
+
+	var confirm string
+	fmt.Println("please confirm,input y/n")
+	if err = scanWord(&confirm); err != nil {
+		return err
+	}
+
+	if confirm == "y" {
+		fmt.Printf("data is: %s\n", data)
+	} else {
+		fmt.Println("please input data hex:")
+		if data, err = readLine(); err != nil {
+			return err
+		}
+		fmt.Printf("data is: %s\n", data)
+		ins.File = ""
+	}
+	ins.Data = data
+	return nil
+}
+
+func promptTimes(ins *config.Inscription) {
+	fmt.Printf("The default number of inscriptions is %d and the interval between each inscription is %d second\n", config.DefaultTimes, config.DefaultDelay)
+	fmt.Println("input y/n: confirm-y, modify-n")
+
+	var confirm string
+	fmt.Scanln(&confirm)
+	if confirm == "y" {
+		ins.Times = config.DefaultTimes
+		ins.Delay = config.DefaultDelay
+		return
+	}
+
+	fmt.Println("please input the number of inscriptions:")
+	fmt.Scanln(&ins.Times)
+	fmt.Println("please input the time interval for each inscription:")
+	fmt.Scanln(&ins.Delay)
+}
+
+// readLine reads a whole line of the terminal, unlike fmt.Scanln it keeps the spaces.
+// It returns io.EOF when the input is closed before a line.
+func readLine() (string, error) {
+	var line []byte
+	b := make([]byte, 1)
+	for {
+		// byte by byte so that nothing is buffered away from the next fmt.Scanln
+		n, err := os.Stdin.Read(b)
+		if n == 0 || err != nil {
+			if len(line) == 0 {
+				return "", errInputClosed
+			}
+			break
+		}
+		if b[0] == '\n' {
+			break
+		}
+		line = append(line, b[0])
+	}
+	return strings.TrimRight(string(line), "\r"), nil
+}
+
+// scanWord reads a word of the terminal like fmt.Scanln, an empty line leaves the value as it is.
+// It returns io.EOF when the input is closed.
+func scanWord(value *string) error {
+	if _, err := fmt.Scanln(value); errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return errInputClosed
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"errors"
+	"inscription/chain/util"
+	"inscription/config"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// withStdin runs the prompt with the input read from a file
+func withStdin(t *testing.T, input string, prompt func() error) error {
+	path := filepath.Join(t.TempDir(), "stdin")
+	if err := os.WriteFile(path, []byte(input), 0600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	stdin := os.Stdin
+	os.Stdin = f
+	defer func() { os.Stdin = stdin }()
+	return prompt()
+}
+
+func TestPromptDataClosedInput(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"nothing", ""},
+		{"text without confirmation", "1\nhello\n"},
+		{"operation cut short", "3\nerc-20\n"},
+		// the invalid tick asks again
+		{"invalid operation", "3\nerc-20\net hs\n1\n"},
+		{"file path", "5\n"},
+		{"missing file", "5\n" + filepath.Join(t.TempDir(), "missing.png") + "\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := withStdin(t, test.input, func() error { return promptData(config.NewInscription()) })
+			if !errors.Is(err, io.EOF) {
+				t.Fatalf("expect the input to be closed, got %v", err)
+			}
+		})
+	}
+
+	ins := config.NewInscription()
+	if err := withStdin(t, "1\nhello world\ny\n", func() error { return promptData(ins) }); err != nil {
+		t.Fatal(err)
+	}
+	if ins.Data != util.TextToHex("hello world") {
+		t.Fatalf("data %s, want the hex of the text", ins.Data)
+	}
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"inscription/app"
+	"inscription/chain/eth/core"
+	"inscription/config"
+	"inscription/feature"
+	"inscription/journal"
+	"time"
+)
+
+func runSpeedUp(args []string) error {
+	return replace("speedup", args, false)
+}
+
+func runCancel(args []string) error {
+	return replace("cancel", args, true)
+}
+
+func replace(name string, args []string, cancel bool) error {
+	var hash string
+	ins, err := parseConfig(name, args, func(fs *flag.FlagSet, ins *config.Inscription) {
+		keystoreFlags(fs, ins)
+		fs.StringVar(&hash, "hash", hash, "hash of the pending tx")
+		fs.IntVar(&ins.ReplaceBump, "bump", ins.ReplaceBump, "fee increase over the pending tx, percent")
+		fs.StringVar(&ins.GasPrice, "gas-price", ins.GasPrice, "optional floor of the new gas price or max fee per gas, wei")
+		fs.StringVar(&ins.MaxPriorityFeePerGas, "max-priority-fee", ins.MaxPriorityFeePerGas, "optional floor of the new max priority fee per gas, wei")
+		fs.IntVar(&ins.Confirmations, "confirmations", ins.Confirmations, "blocks to wait for the receipt, 0 doesn't wait")
+		fs.IntVar(&ins.ReceiptTimeout, "receipt-timeout", ins.ReceiptTimeout, "the longest time to wait for the receipt, second")
+	})
+	if err != nil {
+		return err
+	}
+	if ins.RpcUrl == "" || hash == "" {
+		return errors.New("rpc and hash are required")
+	}
+	account, err := loadAccount(ins)
+	if err != nil {
+		return err
+	}
+
+	evmApp, err := newApp(ins)
+	if err != nil {
+		return err
+	}
+	txSign, err := evmApp.ReplaceTx(account, hash, replacement(ins, cancel))
+	if err != nil {
+		return err
+	}
+	app.LogInfof("%s %s with nonce %d,hash: %s", name, hash, txSign.SignedTx.Nonce(), txSign.TxHex)
+
+	tracker := evmApp.NewReceiptTracker(ins.Confirmations, ins.ReceiptTimeout)
+	receipt := tracker.Track(txSign)
+	receipt.Replaced = []string{hash}
+	if ins.Confirmations > 0 {
+		app.PrintReceipts(tracker.Wait(context.Background()))
+	}
+	return nil
+}
+
+func replacement(ins *config.Inscription, cancel bool) *feature.Replacement {
+	return &feature.Replacement{
+		BumpPercent:          ins.ReplaceBump,
+		Cancel:               cancel,
+		GasPrice:             ins.GasPrice,
+		MaxPriorityFeePerGas: ins.MaxPriorityFeePerGas,
+	}
+}
+
+// speedUpStuck
+//
+//	@Description: let the tracker speed up the txs which are pending longer than the stuck timeout
+//	@param evmApp
+//	@param tracker
+//	@param ins
+//	@param accounts the accounts which sent the tracked txs, by address
+//	@param j journal recording the speed up txs, may be nil
+func speedUpStuck(evmApp *app.App, tracker *core.ReceiptTracker, ins *config.Inscription, accounts map[string]*core.Account, j *journal.Journal) {
+	if ins.StuckTimeout <= 0 {
+		return
+	}
+	tracker.OnStuck(time.Duration(ins.StuckTimeout)*time.Second, func(receipt *core.TxReceipt) (*core.BuildTxResult, error) {
+		account, ok := accounts[receipt.From]
+		if !ok {
+			return nil, fmt.Errorf("no key of %s", receipt.From)
+		}
+		// the fee floor of the run is left out, it may be below the bumped fee of the pending tx
+		txSign, err := evmApp.ReplaceTx(account, receipt.Hash, &feature.Replacement{BumpPercent: ins.ReplaceBump})
+		if err != nil {
+			app.LogErrorf("speed up %s failed,reason: %s", receipt.Hash, err)
+			return nil, err
+		}
+		app.LogInfof("speed up %s with nonce %d,hash: %s", receipt.Hash, receipt.Nonce, txSign.TxHex)
+		if j != nil {
+			if err := j.Replacement(txSign, receipt.Hashes()); err != nil {
+				app.LogErrorf("write the journal failed,reason: %s", err)
+			}
+		}
+		return txSign, nil
+	})
+}
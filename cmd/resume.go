@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"errors"
+	"inscription/app"
+	"inscription/chain/eth/core"
+	"inscription/config"
+	"inscription/journal"
+)
+
+// runResume
+//
+//	@Description: continue the last run of the journal with the inscriptions it didn't make, or the items for a drop.
+//	The config of the run is the default, the secrets it left out are given by the flags or the prompt.
+//	@param args
+//	@return error
+func runResume(args []string) (err error) {
+	var entries []*journal.Entry
+	ins, err := parseConfigOver("resume", args, func(flags *config.Inscription) (*config.Inscription, error) {
+		if flags.Journal == "" {
+			return nil, errors.New("journal is required")
+		}
+		var err error
+		if entries, err = journal.Read(flags.Journal); err != nil {
+			return nil, err
+		}
+		run, _, err := journal.LastRun(entries)
+		return run, err
+	}, mintFlags)
+	if err != nil {
+		return
+	}
+	if ins.Manifest != "" {
+		return resumeDrop(ins, entries)
+	}
+	if err = requireConfig(ins); err != nil {
+		return
+	}
+	if err = checkMintConfig(ins); err != nil {
+		return
+	}
+	jobs, err := mintJobs(ins)
+	if err != nil {
+		return
+	}
+
+	evmApp, err := newApp(ins)
+	if err != nil {
+		return err
+	}
+	if err = prepareGasLimit(evmApp, ins, jobs); err != nil {
+		return
+	}
+	_, runEntries, err := journal.LastRun(entries)
+	if err != nil {
+		return
+	}
+	remaining, err := resumeJobs(evmApp, jobs, runEntries)
+	if err != nil {
+		return
+	}
+	if len(remaining) == 0 {
+		app.LogInfof("nothing left to mint")
+		return nil
+	}
+
+	j, err := journal.Open(ins.Journal)
+	if err != nil {
+		return
+	}
+	defer j.Close()
+	if err = j.Resume(); err != nil {
+		return
+	}
+	targets := journal.Jobs(runEntries)
+	for _, job := range remaining {
+		if _, ok := targets[job.Account.Address]; !ok {
+			// a wallet the run didn't have
+			if err = j.Job(job.Account.Address, job.Times); err != nil {
+				return
+			}
+		}
+	}
+	return runJobs(evmApp, ins, remaining, j)
+}
+
+// resumeJobs
+//
+//	@Description: check the txs of the run on chain and print them, then the jobs with the inscriptions the run didn't make
+//	@param evmApp
+//	@param jobs the jobs of the config
+//	@param runEntries the entries of the run
+//	@return []*app.MintJob
+//	@return error
+func resumeJobs(evmApp *app.App, jobs []*app.MintJob, runEntries []*journal.Entry) ([]*app.MintJob, error) {
+	receipts, err := evmApp.Reconcile(runEntries)
+	if err != nil {
+		return nil, err
+	}
+	app.LogInfof("the journal has %d txs of the run", len(receipts))
+	if len(receipts) > 0 {
+		app.PrintReceipts(receipts)
+	}
+	return remainingJobs(jobs, journal.Jobs(runEntries), runEntries, receipts), nil
+}
+
+// remainingJobs
+//
+//	@Description: the jobs with the indexes of the inscriptions the run didn't make, the ones without any are left out.
+//	The inscriptions are the ones of the job of the account in the run, the config ones for an account the run didn't have.
+//	@param jobs the jobs of the config
+//	@param targets the number of inscriptions of each account in the run
+//	@param runEntries
+//	@param receipts the reconciled receipts of the run
+//	@return []*app.MintJob
+func remainingJobs(jobs []*app.MintJob, targets map[string]int, runEntries []*journal.Entry, receipts []*core.TxReceipt) []*app.MintJob {
+	indexOf := make(map[string]int)
+	for _, entry := range runEntries {
+		if entry.Kind == journal.KindTx && entry.Index > 0 && entry.Hash != "" {
+			indexOf[entry.Hash] = entry.Index
+		}
+	}
+	// a pending tx may still be mined, its inscription is counted as made to never mint it twice
+	made := make(map[string]map[int]bool)
+	for _, receipt := range receipts {
+		if receipt.Status != core.TxConfirmed && receipt.Status != core.TxPending {
+			continue
+		}
+		for _, hash := range receipt.Hashes() {
+			if i, ok := indexOf[hash]; ok {
+				if made[receipt.From] == nil {
+					made[receipt.From] = make(map[int]bool)
+				}
+				made[receipt.From][i] = true
+			}
+		}
+	}
+
+	remaining := make([]*app.MintJob, 0, len(jobs))
+	for _, job := range jobs {
+		address := job.Account.Address
+		times := job.Times
+		if target, ok := targets[address]; ok {
+			times = target
+		}
+		job.Indexes = nil
+		for i := 1; i <= times; i++ {
+			if !made[address][i] {
+				job.Indexes = append(job.Indexes, i)
+			}
+		}
+		job.Times = len(job.Indexes)
+		if job.Times == 0 {
+			app.LogInfof("%s has made its %d inscriptions", address, times)
+			continue
+		}
+		app.LogInfof("%s has made %d inscriptions, %d left", address, times-job.Times, job.Times)
+		remaining = append(remaining, job)
+	}
+	return remaining
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"crypto/ecdsa"
+	"github.com/ethereum/go-ethereum/crypto"
+	"inscription/app"
+	"inscription/chain/eth/core"
+	"inscription/chain/eth/core/coretest"
+	"inscription/journal"
+	"reflect"
+	"testing"
+)
+
+func TestRemainingJobs(t *testing.T) {
+	newJobs := func() []*app.MintJob {
+		return []*app.MintJob{
+			{Account: &core.Account{Address: "0xa"}, Times: 9, Recipients: []string{"0x1", "0x2", "0x3"}},
+			{Account: &core.Account{Address: "0xb"}, Times: 9},
+			{Account: &core.Account{Address: "0xc"}, Times: 2},
+		}
+	}
+	targets := map[string]int{"0xa": 6, "0xb": 2}
+	// 0xa failed in the middle of the run: the 3rd was never sent, the 4th was dropped, the 6th isn't sent yet
+	entries := []*journal.Entry{
+		{Kind: journal.KindJob, Account: "0xa", Times: 6},
+		{Kind: journal.KindJob, Account: "0xb", Times: 2},
+		{Kind: journal.KindTx, Account: "0xa", Index: 1, Nonce: 0, Hash: "0xa1", Status: journal.StatusSent},
+		{Kind: journal.KindTx, Account: "0xa", Index: 2, Nonce: 1, Hash: "0xa2", Status: journal.StatusSent},
+		{Kind: journal.KindTx, Account: "0xa", Index: 3, Nonce: 2, Status: journal.StatusFailed, Error: "timeout"},
+		{Kind: journal.KindTx, Account: "0xa", Index: 4, Nonce: 2, Hash: "0xa4", Status: journal.StatusSent},
+		{Kind: journal.KindTx, Account: "0xa", Index: 5, Nonce: 3, Hash: "0xa5", Status: journal.StatusSent},
+		{Kind: journal.KindTx, Account: "0xa", Nonce: 3, Hash: "0xa5b", Status: journal.StatusSent},
+		{Kind: journal.KindTx, Account: "0xb", Index: 1, Nonce: 0, Hash: "0xb1", Status: journal.StatusSent},
+		{Kind: journal.KindTx, Account: "0xb", Index: 2, Nonce: 1, Hash: "0xb2", Status: journal.StatusSent},
+	}
+	receipts := []*core.TxReceipt{
+		{From: "0xa", Nonce: 0, Hash: "0xa1", Status: core.TxConfirmed},
+		{From: "0xa", Nonce: 1, Hash: "0xa2", Status: core.TxPending},
+		{From: "0xa", Nonce: 2, Hash: "0xa4", Status: core.TxDropped},
+		// the 5th was sped up
+		{From: "0xa", Nonce: 3, Hash: "0xa5b", Replaced: []string{"0xa5"}, Status: core.TxConfirmed},
+		{From: "0xb", Nonce: 0, Hash: "0xb1", Status: core.TxConfirmed},
+		{From: "0xb", Nonce: 1, Hash: "0xb2", Status: core.TxReverted},
+	}
+
+	remaining := remainingJobs(newJobs(), targets, entries, receipts)
+	if len(remaining) != 3 {
+		t.Fatalf("expect 3 remaining jobs, got %d", len(remaining))
+	}
+	tests := []struct {
+		address    string
+		indexes    []int
+		recipients []string
+	}{
+		{"0xa", []int{3, 4, 6}, []string{"0x3", "0x1", "0x3"}},
+		{"0xb", []int{2}, []string{"0xb"}},
+		// a wallet the run didn't have makes every inscription of the config
+		{"0xc", []int{1, 2}, []string{"0xc", "0xc"}},
+	}
+	for k, test := range tests {
+		job := remaining[k]
+		if job.Account.Address != test.address || !reflect.DeepEqual(job.Indexes, test.indexes) || job.Times != len(test.indexes) {
+			t.Fatalf("%s: unexpected job %v, times %d", test.address, job.Indexes, job.Times)
+		}
+		for n := range test.indexes {
+			if to := job.Recipient(job.Index(n)); to != test.recipients[n] {
+				t.Fatalf("%s: the %dth inscription goes to %s, want %s", test.address, job.Index(n), to, test.recipients[n])
+			}
+		}
+	}
+
+	// every inscription made or pending leaves nothing
+	for _, receipt := range receipts {
+		receipt.Status = core.TxConfirmed
+	}
+	entries = append(entries,
+		&journal.Entry{Kind: journal.KindTx, Account: "0xa", Index: 3, Nonce: 4, Hash: "0xa3", Status: journal.StatusSent},
+		&journal.Entry{Kind: journal.KindTx, Account: "0xa", Index: 6, Nonce: 5, Hash: "0xa6", Status: journal.StatusSent},
+	)
+	receipts = append(receipts,
+		&core.TxReceipt{From: "0xa", Nonce: 4, Hash: "0xa3", Status: core.TxPending},
+		&core.TxReceipt{From: "0xa", Nonce: 5, Hash: "0xa6", Status: core.TxConfirmed},
+	)
+	remaining = remainingJobs(newJobs()[:2], targets, entries, receipts)
+	if len(remaining) != 0 {
+		t.Fatalf("expect nothing left, got %d jobs", len(remaining))
+	}
+}
+
+func TestResumeJobs(t *testing.T) {
+	node := coretest.NewNode(t, 100, 0)
+	evmApp := app.NewApp(node.URL, 1)
+	if evmApp == nil {
+		t.Fatal("the app can't reach the node")
+	}
+	var keys []*ecdsa.PrivateKey
+	var jobs []*app.MintJob
+	for _, times := range []int{9, 9, 1} {
+		key, _ := crypto.GenerateKey()
+		keys = append(keys, key)
+		jobs = append(jobs, &app.MintJob{Account: &core.Account{Address: crypto.PubkeyToAddress(key.PublicKey).Hex()}, Times: times})
+	}
+	a, b := jobs[0].Account.Address, jobs[1].Account.Address
+
+	confirmed := coretest.SignTx(t, keys[0], 0, 1)
+	pending := coretest.SignTx(t, keys[0], 1, 1)
+	neverSent := coretest.SignTx(t, keys[0], 2, 1)
+	reverted := coretest.SignTx(t, keys[1], 0, 1)
+	mined := coretest.SignTx(t, keys[1], 1, 1)
+	node.Revert(reverted.Hash())
+	node.Include(confirmed, reverted, mined)
+	node.Mine()
+	node.Include(pending)
+
+	tx := func(address string, index int, nonce uint64, hash string, status string) *journal.Entry {
+		return &journal.Entry{Kind: journal.KindTx, Account: address, Index: index, Nonce: nonce, Hash: hash, Status: status}
+	}
+	// the run stopped after the 3rd inscription of a, c isn't in the run
+	entries := []*journal.Entry{
+		{Kind: journal.KindJob, Account: a, Times: 4},
+		{Kind: journal.KindJob, Account: b, Times: 2},
+		tx(a, 1, 0, confirmed.Hash().Hex(), journal.StatusSent),
+		tx(b, 1, 0, reverted.Hash().Hex(), journal.StatusSent),
+		tx(a, 2, 1, pending.Hash().Hex(), journal.StatusSent),
+		tx(b, 2, 1, mined.Hash().Hex(), journal.StatusSent),
+		tx(a, 3, 2, neverSent.Hash().Hex(), journal.StatusFailed),
+	}
+	remaining, err := resumeJobs(evmApp, jobs, entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][]int{
+		a:                       {3, 4},
+		b:                       {1},
+		jobs[2].Account.Address: {1},
+	}
+	if len(remaining) != len(want) {
+		t.Fatalf("%d remaining jobs, want %d", len(remaining), len(want))
+	}
+	for _, job := range remaining {
+		if !reflect.DeepEqual(job.Indexes, want[job.Account.Address]) || job.Times != len(job.Indexes) {
+			t.Fatalf("%s has %v left, want %v", job.Account.Address, job.Indexes, want[job.Account.Address])
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"inscription/app"
+	"inscription/chain/eth/contract"
+	"inscription/chain/util"
+	"inscription/config"
+	"math/big"
+	"time"
+)
+
+// scheduleFlags
+//
+//	@Description: flags of when a mint starts and stops
+//	@param fs
+//	@param schedule
+func scheduleFlags(fs *flag.FlagSet, schedule *config.Schedule) {
+	fs.Uint64Var(&schedule.StartBlock, "start-block", schedule.StartBlock, "the first block the inscriptions may land in, they are sent once the head is the block before")
+	fs.Int64Var(&schedule.StartTime, "start-time", schedule.StartTime, "unix time the inscriptions are sent at")
+	fs.Uint64Var(&schedule.EndBlock, "end-block", schedule.EndBlock, "no more inscription is sent once the head reaches it")
+	fs.StringVar(&schedule.MaxSupply, "max-supply", schedule.MaxSupply, "no more inscription is sent once the supply of the contract reaches it")
+	fs.StringVar(&schedule.SupplyContract, "supply-contract", schedule.SupplyContract, "contract of the supply, the called contract when empty")
+	fs.StringVar(&schedule.SupplyMethod, "supply-method", schedule.SupplyMethod, "view function signature returning the supply")
+	fs.IntVar(&schedule.PreSign, "presign", schedule.PreSign, "inscriptions of each wallet signed before the start and sent the moment it's reached")
+}
+
+// checkSchedule validates the schedule, the max supply is read from a contract
+func checkSchedule(ins *config.Inscription) error {
+	if err := ins.Schedule.Validate(); err != nil {
+		return err
+	}
+	if ins.MaxSupply != "" && ins.SupplyContract == "" && ins.Contract == "" {
+		return errors.New("max supply needs -supply-contract, or -contract to read the supply from")
+	}
+	if ins.SupplyContract != "" {
+		return util.ValidateAddress(ins.SupplyContract)
+	}
+	return nil
+}
+
+// mintSchedule
+//
+//	@Description: the schedule of the config, the supply is read with eth_call of the supply method
+//	@param evmApp
+//	@param ins
+//	@return *app.Schedule
+//	@return error
+func mintSchedule(evmApp *app.App, ins *config.Inscription) (*app.Schedule, error) {
+	schedule := &app.Schedule{
+		StartBlock: ins.StartBlock,
+		EndBlock:   ins.EndBlock,
+	}
+	if ins.StartTime > 0 {
+		schedule.StartTime = time.Unix(ins.StartTime, 0)
+	}
+	maxSupply, err := ins.Schedule.Supply()
+	if err != nil || maxSupply == nil {
+		return schedule, err
+	}
+
+	fn, err := contract.ParseSignature(ins.SupplyMethod)
+	if err != nil {
+		return nil, err
+	}
+	calldata, err := fn.Encode(nil)
+	if err != nil {
+		return nil, err
+	}
+	supplyContract := ins.SupplyContract
+	if supplyContract == "" {
+		supplyContract = ins.Contract
+	}
+	data := util.HexEncodeToString(calldata)
+	schedule.MaxSupply = maxSupply
+	schedule.Supply = func() (*big.Int, error) {
+		ret, err := evmApp.Call(supplyContract, supplyContract, "0", data)
+		if err != nil {
+			return nil, err
+		}
+		if len(ret) < 32 {
+			return nil, fmt.Errorf("%s of %s returned %d bytes, want a uint256", fn.Method.Sig, supplyContract, len(ret))
+		}
+		return new(big.Int).SetBytes(ret[:32]), nil
+	}
+	supply, err := schedule.Supply()
+	if err != nil {
+		return nil, fmt.Errorf("read the supply failed: %w", err)
+	}
+	app.LogInfof("supply %s of the max supply %s", supply, maxSupply)
+	return schedule, nil
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/term"
+	"inscription/config"
+	"os"
+	"strings"
+)
+
+// readSecret
+//
+//	@Description: read a line from the terminal without echo, falls back to a plain read when stdin isn't a terminal
+//	@param prompt
+//	@return string
+//	@return error
+func readSecret(prompt string) (string, error) {
+	fmt.Println(prompt)
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		var secret string
+		_, err := fmt.Scanln(&secret)
+		return secret, err
+	}
+	secret, err := term.ReadPassword(fd)
+	fmt.Println()
+	return strings.TrimSpace(string(secret)), err
+}
+
+// readPassphrase
+//
+//	@Description: keystore passphrase from the file, the env or the terminal, in that order
+//	@param file
+//	@param confirm ask twice on the terminal, for new keystores
+//	@return string
+//	@return error
+func readPassphrase(file string, confirm bool) (string, error) {
+	if file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+	if passphrase := os.Getenv(config.PassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("no passphrase, set -passphrase-file or %s", config.PassphraseEnv)
+	}
+
+	passphrase, err := readSecret("please input the keystore passphrase:")
+	if err != nil {
+		return "", err
+	}
+	if confirm {
+		repeat, err := readSecret("please repeat the keystore passphrase:")
+		if err != nil {
+			return "", err
+		}
+		if repeat != passphrase {
+			return "", errors.New("the passphrases don't match")
+		}
+	}
+	return passphrase, nil
+}
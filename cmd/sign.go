@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/ethereum/go-ethereum/core/types"
+	"inscription/app"
+	"inscription/chain/eth/core"
+	"inscription/config"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// defaultSignedFile is the file sign writes the txs to and broadcast reads them from
+const defaultSignedFile = "signed-txs.txt"
+
+func runSign(args []string) error {
+	var chainId int64
+	var force bool
+	nonce := int64(-1)
+	out := defaultSignedFile
+	ins, err := parseConfig("sign", args, func(fs *flag.FlagSet, ins *config.Inscription) {
+		keystoreFlags(fs, ins)
+		mnemonicFlags(fs, ins)
+		fs.StringVar(&ins.Data, "data", ins.Data, "inscription data in hex")
+		fs.StringVar(&ins.Text, "text", ins.Text, "inscription original text, used when -data is empty")
+		fs.StringVar(&ins.File, "file", ins.File, "file inscribed as a base64 data URI, used when -data and -text are empty")
+		operationFlags(fs, &ins.Operation)
+		contractFlags(fs, ins)
+		fs.StringVar(&ins.Json, "json", ins.Json, "json document inscribed as a data:application/json URI, used when -data, -text, -file and -op are empty")
+		feeFlags(fs, &ins.Fee)
+		fs.StringVar(&ins.GasLimit, "gas-limit", ins.GasLimit, "gas limit, estimated with -rpc for a file or a contract call when empty")
+		fs.StringVar(&ins.To, "to", ins.To, "recipient of every inscription, empty is the wallet itself")
+		fs.StringVar(&ins.ToFile, "to-file", ins.ToFile, "file of recipients (.json, .csv or one per line), one inscription to every recipient")
+		fs.IntVar(&ins.Times, "times", ins.Times, "the number of txs")
+		fs.Int64Var(&chainId, "chain-id", chainId, "chain id of the txs, read from -rpc when 0")
+		fs.Int64Var(&nonce, "nonce", nonce, "nonce of the first tx, the pending nonce read from -rpc when negative")
+		fs.StringVar(&out, "out", out, "file the signed txs are written to, the raw tx in hex one per line")
+		fs.BoolVar(&force, "force", force, "overwrite the out file when it exists")
+	})
+	if err != nil {
+		return err
+	}
+	// the txs of an earlier sign may not be broadcast yet
+	if _, err = os.Stat(out); err == nil && !force {
+		return fmt.Errorf("%s exists, set -force to overwrite it", out)
+	}
+	if err = checkMintConfig(ins); err != nil {
+		return err
+	}
+	jobs, err := mintJobs(ins)
+	if err != nil {
+		return err
+	}
+	if len(jobs) != 1 {
+		return fmt.Errorf("sign takes one account, got %d", len(jobs))
+	}
+	job := jobs[0]
+
+	// without the rpc every value read from the node has to be set
+	var online []string
+	if chainId <= 0 {
+		online = append(online, "chain-id")
+	}
+	if nonce < 0 {
+		online = append(online, "nonce")
+	}
+	if ins.FeeMode == config.FeeModeAuto {
+		online = append(online, "auto fee")
+	}
+	if ins.GasLimit == "" {
+		online = append(online, "gas-limit")
+	}
+	var evmApp *app.App
+	if len(online) > 0 {
+		if ins.RpcUrl == "" {
+			return fmt.Errorf("%s needs -rpc, set them to sign offline", strings.Join(online, ", "))
+		}
+		if evmApp, err = newApp(ins); err != nil {
+			return err
+		}
+		if chainId <= 0 {
+			chainId = evmApp.ChainID().Int64()
+		}
+		if nonce < 0 {
+			pending, err := evmApp.Nonce(job.Account.Address)
+			if err != nil {
+				return err
+			}
+			nonce = int64(pending)
+		}
+		if err = prepareGasLimit(evmApp, ins, jobs); err != nil {
+			return err
+		}
+	}
+	if ins.GasLimit == "" {
+		return errors.New("gas limit is required")
+	}
+
+	gasFee := app.StaticGasFee
+	if evmApp != nil {
+		gasFee = evmApp.GasFee
+	}
+	gasPrice, maxPriorityFeePerGas, err := gasFee(&ins.Fee)
+	if err != nil {
+		return err
+	}
+
+	signed, err := app.SignJob(job, big.NewInt(chainId), uint64(nonce), gasPrice, maxPriorityFeePerGas)
+	if err != nil {
+		return err
+	}
+	txs := make([]*types.Transaction, 0, len(signed))
+	for _, txSign := range signed {
+		txs = append(txs, txSign.SignedTx)
+	}
+	if err = core.WriteRawTxs(out, txs); err != nil {
+		return err
+	}
+	app.LogInfof("%d txs of %s signed for the chain %d, nonce %d to %d", len(txs), job.Account.Address, chainId, nonce, nonce+int64(len(txs))-1)
+	app.LogInfof("max cost: %s eth, written to %s", fromWei(maxCost(txs)), out)
+	return nil
+}
+
+// maxCost is the gas limit at the max fee plus the value of every tx
+func maxCost(txs []*types.Transaction) *big.Int {
+	cost := new(big.Int)
+	for _, tx := range txs {
+		cost.Add(cost, tx.Cost())
+	}
+	return cost
+}
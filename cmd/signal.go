@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"context"
+	"inscription/app"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// interruptContext
+//
+//	@Description: context done on the first Ctrl-C, the txs in flight are still sent and the summary is printed.
+//	The second Ctrl-C abandons them and exits.
+//	@return context.Context
+//	@return context.CancelFunc
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case <-signals:
+			// restore the default behaviour, the next signal terminates the process
+			signal.Stop(signals)
+			app.LogInfo("interrupted, finishing the transactions in flight, press Ctrl-C again to abandon them")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel()
+	}
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"inscription/app"
+	"inscription/config"
+	"inscription/ethscription"
+	"strings"
+)
+
+func runTransfer(args []string) error {
+	var ids, idFile string
+	var each bool
+	ins, err := parseConfig("transfer", args, func(fs *flag.FlagSet, ins *config.Inscription) {
+		keystoreFlags(fs, ins)
+		mnemonicFlags(fs, ins)
+		fs.StringVar(&ins.To, "to", ins.To, "the new owner of the ethscriptions")
+		fs.StringVar(&ids, "id", ids, "ids of the ethscriptions, the hashes of the txs which created them, separated by commas")
+		fs.StringVar(&idFile, "id-file", idFile, "file of ethscription ids (.json, .csv or one per line)")
+		fs.BoolVar(&each, "each", each, "send one tx per ethscription instead of one ESIP-5 bulk transfer")
+		feeFlags(fs, &ins.Fee)
+		fs.StringVar(&ins.GasLimit, "gas-limit", ins.GasLimit, "gas limit of each tx, estimated when empty")
+		fs.IntVar(&ins.Confirmations, "confirmations", ins.Confirmations, "blocks to wait for each receipt, 0 doesn't wait")
+		fs.IntVar(&ins.ReceiptTimeout, "receipt-timeout", ins.ReceiptTimeout, "the longest time to wait for the receipts, second")
+	})
+	if err != nil {
+		return err
+	}
+	if ins.RpcUrl == "" || ins.To == "" {
+		return errors.New("rpc and to are required")
+	}
+	if err = ins.Fee.Validate(); err != nil {
+		return err
+	}
+	if missing := ins.Fee.Missing(); len(missing) > 0 {
+		return fmt.Errorf("missing required config: %s", strings.Join(missing, ", "))
+	}
+	ethscriptions, err := ethscriptionIDs(ids, idFile)
+	if err != nil {
+		return err
+	}
+	// every id is checked before the first tx
+	if _, err = ethscription.TransferData(ethscriptions); err != nil {
+		return err
+	}
+	account, err := loadAccount(ins)
+	if err != nil {
+		return err
+	}
+
+	evmApp, err := newApp(ins)
+	if err != nil {
+		return err
+	}
+	batches := [][]string{ethscriptions}
+	if each {
+		batches = make([][]string, 0, len(ethscriptions))
+		for _, id := range ethscriptions {
+			batches = append(batches, []string{id})
+		}
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+
+	tracker := evmApp.NewReceiptTracker(ins.Confirmations, ins.ReceiptTimeout)
+	failed := 0
+	for i, batch := range batches {
+		if ctx.Err() != nil {
+			for _, skipped := range batches[i:] {
+				failed += len(skipped)
+			}
+			break
+		}
+		txSign, err := evmApp.TransferEthscriptions(account, ins.To, batch, &ins.Fee, ins.GasLimit)
+		if err != nil {
+			app.LogErrorf("transfer %d ethscriptions to %s failed,reason: %s", len(batch), ins.To, err)
+			failed += len(batch)
+			continue
+		}
+		tracker.Track(txSign)
+		app.LogInfof("transfer %d ethscriptions to %s sent,hash: %s", len(batch), ins.To, txSign.TxHex)
+	}
+	if ins.Confirmations > 0 {
+		app.PrintReceipts(tracker.Wait(ctx))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d ethscriptions are not transferred", failed)
+	}
+	return nil
+}
+
+// ethscriptionIDs returns the ids of the flag and of the file, they're validated when the transfer data is built
+func ethscriptionIDs(ids string, idFile string) ([]string, error) {
+	var list []string
+	for _, id := range strings.Split(ids, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			list = append(list, id)
+		}
+	}
+	if idFile != "" {
+		fromFile, err := config.LoadEthscriptionIDs(idFile)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, fromFile...)
+	}
+	if len(list) == 0 {
+		return nil, errors.New("no ethscription id, set id or id-file")
+	}
+	return list, nil
+}
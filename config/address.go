@@ -0,0 +1,64 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadAddresses
+//
+//	@Description: read the addresses of the file, .json is an array of addresses, .csv has the address in the
+//	first column and anything else holds one address per line. Empty lines and lines starting with # are skipped.
+//	@param path
+//	@return []string
+//	@return error
+func LoadAddresses(path string) ([]string, error) {
+	return loadList(path, "address", "address")
+}
+
+// LoadEthscriptionIDs
+//
+//	@Description: read the ethscription ids of the file, in the same layouts as LoadAddresses with an id header in a .csv
+//	@param path
+//	@return []string
+//	@return error
+func LoadEthscriptionIDs(path string) ([]string, error) {
+	return loadList(path, "id", "ethscription id")
+}
+
+// loadList reads the first column of the file, the first line which isn't empty or a comment is skipped when it's the header
+func loadList(path string, header string, name string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []string
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		if err := json.Unmarshal(content, &list); err != nil {
+			return nil, fmt.Errorf("%s is not a json array of %s: %w", path, name, err)
+		}
+	} else {
+		first := true
+		for _, line := range strings.Split(string(content), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			item := strings.TrimSpace(strings.Split(line, ",")[0])
+			if first && strings.EqualFold(item, header) {
+				first = false
+				continue
+			}
+			first = false
+			list = append(list, item)
+		}
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("no %s in %s", name, path)
+	}
+	return list, nil
+}
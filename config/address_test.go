@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadEthscriptionIDs(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	want := []string{"0x01", "0x02"}
+	for _, path := range []string{
+		write("ids.csv", "id,tick\n0x01,eths\n# skipped\n\n0x02,eths\n"),
+		write("ids.txt", "0x01\n0x02\n"),
+		write("comment.csv", "\n# exported ids\nID,tick\n0x01,eths\n0x02,eths\n"),
+		write("ids.json", `["0x01","0x02"]`),
+	} {
+		ids, err := LoadEthscriptionIDs(path)
+		if err != nil || !reflect.DeepEqual(ids, want) {
+			t.Fatalf("%s: %v %v", path, ids, err)
+		}
+	}
+
+	for _, path := range []string{write("empty.csv", "id\n"), write("bad.json", `{"id":"0x01"}`)} {
+		if _, err := LoadEthscriptionIDs(path); err == nil || !strings.Contains(err.Error(), "ethscription id") {
+			t.Fatalf("%s: expect an error about the ids, got %v", path, err)
+		}
+	}
+}
+
+func TestLoadAddresses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "to.csv")
+	if err := os.WriteFile(path, []byte("# recipients\n\naddress,name\n0x01,a\naddress\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	// only the first line may be the header
+	addresses, err := LoadAddresses(path)
+	if err != nil || !reflect.DeepEqual(addresses, []string{"0x01", "address"}) {
+		t.Fatalf("%v %v", addresses, err)
+	}
+}
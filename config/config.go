@@ -4,4 +4,5 @@ const (
 	DefaultContractGasLimit = "63000"
 	DefaultEthGasLimit      = "21000"
 	GasFactor               = 2
+	MinReplaceBump          = 10 // percent, the minimum fee increase the nodes accept for a replacement tx
 )
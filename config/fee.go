@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	FeeModeLegacy  = "legacy" // gas price tx
+	FeeModeDynamic = "1559"   // EIP-1559 tx with the max fee and tip set by the user
+	FeeModeAuto    = "auto"   // EIP-1559 tx with the max fee and tip estimated from the chain
+
+	DefaultFeeMultiplier = 2
+)
+
+type Fee struct {
+	FeeMode              string  `json:"feeMode"`
+	GasPrice             string  `json:"gasPrice"`             // gas price of legacy tx, max fee per gas of 1559 tx, wei
+	MaxPriorityFeePerGas string  `json:"maxPriorityFeePerGas"` // tip of 1559 tx, wei
+	FeeMultiplier        float64 `json:"feeMultiplier"`        // auto mode, max fee = base fee * multiplier + tip
+	MaxFeeCap            string  `json:"maxFeeCap"`            // auto mode, upper bound of the max fee per gas, wei
+	PriorityFeeCap       string  `json:"priorityFeeCap"`       // auto mode, upper bound of the tip, wei
+}
+
+// NewFee
+//
+//	@Description: legacy fee with the default auto multiplier
+//	@return Fee
+func NewFee() Fee {
+	return Fee{
+		FeeMode:       FeeModeLegacy,
+		FeeMultiplier: DefaultFeeMultiplier,
+	}
+}
+
+// Validate
+//
+//	@Description: check the fee mode and its required fields
+//	@receiver f
+//	@return error
+func (f *Fee) Validate() error {
+	switch f.FeeMode {
+	case FeeModeLegacy, FeeModeDynamic, FeeModeAuto:
+	default:
+		return fmt.Errorf("unknown fee mode %q, should be %s, %s or %s", f.FeeMode, FeeModeLegacy, FeeModeDynamic, FeeModeAuto)
+	}
+	if missing := f.Missing(); len(missing) > 0 {
+		return fmt.Errorf("%s is required in %s fee mode", missing[0], f.FeeMode)
+	}
+	if f.FeeMode == FeeModeAuto {
+		if math.IsNaN(f.FeeMultiplier) || math.IsInf(f.FeeMultiplier, 0) {
+			return fmt.Errorf("fee multiplier %v is not a number", f.FeeMultiplier)
+		}
+		if f.FeeMultiplier < 1 {
+			return fmt.Errorf("fee multiplier %v can't be less than 1", f.FeeMultiplier)
+		}
+	}
+	return nil
+}
+
+// Missing
+//
+//	@Description: names of the fields required by the fee mode which are still empty
+//	@receiver f
+//	@return []string
+func (f *Fee) Missing() []string {
+	var missing []string
+	if f.FeeMode != FeeModeAuto && f.GasPrice == "" {
+		missing = append(missing, "gasPrice")
+	}
+	if f.FeeMode == FeeModeDynamic && f.MaxPriorityFeePerGas == "" {
+		missing = append(missing, "maxPriorityFeePerGas")
+	}
+	return missing
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFeeValidate(t *testing.T) {
+	valid := []Fee{
+		{FeeMode: FeeModeLegacy, GasPrice: "1"},
+		{FeeMode: FeeModeDynamic, GasPrice: "2", MaxPriorityFeePerGas: "1"},
+		{FeeMode: FeeModeAuto, FeeMultiplier: 1.5},
+	}
+	for _, fee := range valid {
+		if err := fee.Validate(); err != nil {
+			t.Fatalf("%+v: %s", fee, err)
+		}
+	}
+
+	invalid := []Fee{
+		{FeeMode: "eip1559", GasPrice: "1"},
+		{FeeMode: FeeModeLegacy},
+		{FeeMode: FeeModeDynamic, GasPrice: "2"},
+		{FeeMode: FeeModeAuto, FeeMultiplier: 0.5},
+		{FeeMode: FeeModeAuto, FeeMultiplier: math.NaN()},
+		{FeeMode: FeeModeAuto, FeeMultiplier: math.Inf(1)},
+	}
+	for _, fee := range invalid {
+		if err := fee.Validate(); err == nil {
+			t.Fatalf("%+v should be invalid", fee)
+		}
+	}
+}
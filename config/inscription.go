@@ -1,12 +1,164 @@
 package config
 
+import (
+	"encoding/json"
+	"errors"
+	"inscription/ethscription"
+	"os"
+)
+
+// PassphraseEnv is the env holding the keystore passphrase
+const PassphraseEnv = "INSCRIBE_PASSPHRASE"
+
+// DefaultDerivationPath is the path template of the accounts derived from a mnemonic
+const DefaultDerivationPath = "m/44'/60'/0'/0/{index}"
+
+// DefaultJournal is the file every run of mint appends its txs to
+const DefaultJournal = "inscribe-journal.jsonl"
+
+const (
+	DefaultTimes          = 1
+	DefaultDelay          = 1
+	DefaultConfirmations  = 1
+	DefaultReceiptTimeout = 120
+	DefaultWorkers        = 1
+	DefaultPipeline       = 1
+)
+
 type Inscription struct {
-	Times      int
-	Delay      int
-	PrivateKey string
-	GasPrice   string
-	GasLimit   string
-	Data       string
-	RpcUrl     string
-	//MaxPriorityFeePerGas string
+	Fee
+	Submission
+	Schedule
+	Times      int    `json:"times"`
+	Delay      int    `json:"delay"`
+	PrivateKey string `json:"privateKey"`
+	KeyFile    string `json:"keyFile"`  // private keys or mnemonics of several wallets, replaces PrivateKey
+	Keystore   string `json:"keystore"` // encrypted keystore file or directory, replaces KeyFile and PrivateKey
+	GasLimit   string `json:"gasLimit"`
+	Data       string `json:"data"` // hex calldata, takes precedence over Text
+	Text       string `json:"text"` // original text, hex encoded into Data when Data is empty
+	File       string `json:"file"` // file inscribed as a base64 data URI when Data and Text are empty
+	Json       string `json:"json"` // json document inscribed as a data:application/json URI when Data, Text, File and Operation are empty
+	RpcUrl     string `json:"rpcUrl"`
+	To         string `json:"to"`     // recipient of every inscription, empty is the account itself
+	ToFile     string `json:"toFile"` // recipients of the inscriptions of each wallet in turn, replaces To
+
+	Operation ethscription.Operation `json:"operation"` // token operation inscribed when Data, Text and File are empty, used when op is set
+
+	Contract string   `json:"contract"` // mint by calling the contract instead of inscribing, it receives every tx
+	Abi      string   `json:"abi"`      // ABI json of the contract, otherwise Method is a function signature
+	Method   string   `json:"method"`   // function name of the ABI or signature like mint(uint256,address), encoded into Data when Data is empty
+	Args     []string `json:"args"`     // arguments of the function
+	Value    string   `json:"value"`    // eth sent with every call
+
+	PassphraseFile string `json:"passphraseFile"` // file of the keystore passphrase, otherwise PassphraseEnv or the terminal
+
+	Mnemonic           string `json:"mnemonic"`           // derive the wallets from it, replaces PrivateKey
+	MnemonicPassphrase string `json:"mnemonicPassphrase"` // optional BIP-39 passphrase
+	DerivationPath     string `json:"derivationPath"`     // path template with the placeholder {index}
+	AccountStart       int    `json:"accountStart"`       // first index of the derived accounts
+	AccountCount       int    `json:"accountCount"`       // number of the derived accounts
+
+	Workers   int     `json:"workers"`   // wallets minting at the same time
+	Pipeline  int     `json:"pipeline"`  // txs of a wallet signed and sent at the same time
+	RateLimit float64 `json:"rateLimit"` // requests per second sent to each rpc node, 0 is unlimited
+	Broadcast bool    `json:"broadcast"` // send every tx to all the nodes of the rpc url
+	WsUrl     string  `json:"wsUrl"`     // websocket of the node following the new heads and the mempool, the rpc url when it's a ws one
+
+	Confirmations  int `json:"confirmations"`  // blocks to wait for each receipt, 0 doesn't wait
+	ReceiptTimeout int `json:"receiptTimeout"` // the longest time to wait for the receipts, second
+	StuckTimeout   int `json:"stuckTimeout"`   // speed up the txs pending longer than it while waiting for the receipts, second, 0 never
+	ReplaceBump    int `json:"replaceBump"`    // fee increase of the speed up and cancel tx, percent
+
+	SkipPreflight bool `json:"skipPreflight"` // start without simulating the txs and checking the balances against their cost
+
+	Journal  string `json:"journal"`  // jsonl file recording the txs of the run for resume, empty records nothing
+	Manifest string `json:"manifest"` // directory, .json or .csv of the items of a drop
+}
+
+// NewInscription
+//
+//	@Description: inscription config filled with the defaults
+//	@return *Inscription
+func NewInscription() *Inscription {
+	return &Inscription{
+		Fee:        NewFee(),
+		Submission: NewSubmission(),
+		Schedule:   NewSchedule(),
+		Times:      DefaultTimes,
+		Delay:      DefaultDelay,
+
+		Operation: ethscription.Operation{P: ethscription.DefaultProtocol},
+
+		DerivationPath: DefaultDerivationPath,
+		AccountCount:   1,
+
+		Workers:  DefaultWorkers,
+		Pipeline: DefaultPipeline,
+
+		Confirmations:  DefaultConfirmations,
+		ReceiptTimeout: DefaultReceiptTimeout,
+		ReplaceBump:    MinReplaceBump,
+
+		Journal: DefaultJournal,
+	}
+}
+
+// LoadInscription
+//
+//	@Description: overwrite the fields of ins with the ones present in the json config file
+//	@param path
+//	@param ins
+//	@return error
+func LoadInscription(path string, ins *Inscription) error {
+	if path == "" {
+		return errors.New("config path can't be empty")
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, ins)
+}
+
+// HasAccount
+//
+//	@Description: whether any source of accounts is set
+//	@receiver i
+//	@return bool
+func (i *Inscription) HasAccount() bool {
+	return i.PrivateKey != "" || i.KeyFile != "" || i.Keystore != "" || i.Mnemonic != ""
+}
+
+// HasPayload
+//
+//	@Description: whether any source of the inscription data is set
+//	@receiver i
+//	@return bool
+func (i *Inscription) HasPayload() bool {
+	return i.Data != "" || i.Text != "" || i.File != "" || i.Json != "" || i.Operation.Op != ""
+}
+
+// Missing
+//
+//	@Description: names of the required fields which are still empty
+//	@receiver i
+//	@return []string
+func (i *Inscription) Missing() []string {
+	var missing []string
+	if !i.HasAccount() {
+		missing = append(missing, "privateKey")
+	}
+	if !i.HasPayload() && i.Method == "" {
+		missing = append(missing, "data")
+	}
+	if i.RpcUrl == "" {
+		missing = append(missing, "rpcUrl")
+	}
+	missing = append(missing, i.Fee.Missing()...)
+	if i.GasLimit == "" && i.File == "" && i.Contract == "" {
+		// the gas limit of a file or a contract call is estimated
+		missing = append(missing, "gasLimit")
+	}
+	return missing
 }
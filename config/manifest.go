@@ -0,0 +1,154 @@
+package config
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Item is one inscription of a drop
+type Item struct {
+	Name                 string `json:"name"`                 // the item in the progress, the file name or the line number by default
+	Text                 string `json:"text"`                 // payload text, e.g. a data URI
+	File                 string `json:"file"`                 // file inscribed as a base64 data URI instead of Text, relative to the manifest
+	To                   string `json:"to"`                   // recipient, empty is the sender itself
+	GasPrice             string `json:"gasPrice"`             // optional fee of the item, gas price or max fee per gas
+	MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas"` // optional tip of the item, makes it an EIP-1559 tx
+}
+
+// manifestColumns are the csv columns of the item fields
+var manifestColumns = []string{"name", "text", "file", "to", "gasPrice", "maxPriorityFeePerGas"}
+
+// LoadManifest
+//
+//	@Description: read the items of the manifest: every file of a directory in the order of the names,
+//	a .json array of items or a .csv with a header naming the columns name,text,file,to,gasPrice,maxPriorityFeePerGas
+//	@param path file or directory
+//	@return []*Item
+//	@return error
+func LoadManifest(path string) ([]*Item, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*Item
+	switch {
+	case info.IsDir():
+		items, err = dirItems(path)
+	case strings.EqualFold(filepath.Ext(path), ".json"):
+		items, err = parseJSONItems(path)
+	case strings.EqualFold(filepath.Ext(path), ".csv"):
+		items, err = parseCSVItems(path)
+	default:
+		return nil, fmt.Errorf("unknown manifest %s, expect a directory, .json or .csv", path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no item in %s", path)
+	}
+
+	names := make(map[string]bool, len(items))
+	for i, item := range items {
+		if item.Name == "" {
+			item.Name = fmt.Sprint(i + 1)
+		}
+		if names[item.Name] {
+			return nil, fmt.Errorf("duplicate item %s", item.Name)
+		}
+		names[item.Name] = true
+		if (item.Text == "") == (item.File == "") {
+			return nil, fmt.Errorf("item %s needs either a text or a file", item.Name)
+		}
+		if item.File != "" && !info.IsDir() && !filepath.IsAbs(item.File) {
+			item.File = filepath.Join(filepath.Dir(path), item.File)
+		}
+	}
+	return items, nil
+}
+
+func dirItems(dir string) ([]*Item, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var items []*Item
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		items = append(items, &Item{Name: entry.Name(), File: filepath.Join(dir, entry.Name())})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+	return items, nil
+}
+
+func parseJSONItems(path string) ([]*Item, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var items []*Item
+	if err := json.Unmarshal(content, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func parseCSVItems(path string) ([]*Item, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	known := 0
+	for _, name := range manifestColumns {
+		if _, ok := columns[name]; ok {
+			known++
+		}
+	}
+	if known == 0 {
+		return nil, fmt.Errorf("the first line of %s names none of the columns %s", path, strings.Join(manifestColumns, ","))
+	}
+
+	items := make([]*Item, 0, len(records)-1)
+	for _, record := range records[1:] {
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		items = append(items, &Item{
+			Name:                 field("name"),
+			Text:                 field("text"),
+			File:                 field("file"),
+			To:                   field("to"),
+			GasPrice:             field("gasPrice"),
+			MaxPriorityFeePerGas: field("maxPriorityFeePerGas"),
+		})
+	}
+	return items, nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadManifest(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"drop.csv":  "name,text,file,to,gasPrice\na,\"data:,a, b\",,0x01,\n,,img/1.png,,30\n",
+		"drop.json": `[{"name":"a","text":"data:,a, b","to":"0x01"},{"file":"img/1.png","gasPrice":"30"}]`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		items, err := LoadManifest(path)
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if len(items) != 2 || items[0].Name != "a" || items[0].Text != "data:,a, b" || items[0].To != "0x01" {
+			t.Fatalf("%s: unexpected first item %+v", name, items[0])
+		}
+		if items[1].Name != "2" || items[1].File != filepath.Join(dir, "img/1.png") || items[1].GasPrice != "30" {
+			t.Fatalf("%s: unexpected second item %+v", name, items[1])
+		}
+	}
+
+	images := filepath.Join(dir, "img")
+	if err := os.Mkdir(images, 0700); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"2.png", "1.png", ".hidden"} {
+		if err := os.WriteFile(filepath.Join(images, name), []byte("x"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	items, err := LoadManifest(images)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].Name != "1.png" || items[1].File != filepath.Join(images, "2.png") {
+		t.Fatalf("unexpected directory items %+v %+v", items[0], items[1])
+	}
+
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte(`[{"name":"a","text":"x"},{"name":"a","text":"y"}]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadManifest(bad); err == nil {
+		t.Fatal("expect a duplicate item error")
+	}
+}
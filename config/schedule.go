@@ -0,0 +1,86 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	DefaultSupplyMethod = "totalSupply()"
+	DefaultPreSign      = 1
+)
+
+type Schedule struct {
+	StartBlock     uint64 `json:"startBlock"`     // the first block the txs may land in, 0 doesn't wait for a block
+	StartTime      int64  `json:"startTime"`      // unix time the txs are sent at, 0 doesn't wait
+	EndBlock       uint64 `json:"endBlock"`       // no more tx is sent once the head reaches it, 0 never stops
+	MaxSupply      string `json:"maxSupply"`      // no more tx is sent once the supply reaches it, empty never stops
+	SupplyContract string `json:"supplyContract"` // contract of the supply, the called contract when empty
+	SupplyMethod   string `json:"supplyMethod"`   // view function signature returning the supply
+	PreSign        int    `json:"preSign"`        // inscriptions of each wallet signed before the start and sent the moment it's reached
+}
+
+// NewSchedule
+//
+//	@Description: schedule starting at once with the default supply method
+//	@return Schedule
+func NewSchedule() Schedule {
+	return Schedule{
+		SupplyMethod: DefaultSupplyMethod,
+		PreSign:      DefaultPreSign,
+	}
+}
+
+// HasStart
+//
+//	@Description: whether the mint waits for a block or a time
+//	@receiver s
+//	@return bool
+func (s *Schedule) HasStart() bool {
+	return s.StartBlock > 0 || s.StartTime > 0
+}
+
+// Supply
+//
+//	@Description: the max supply, nil when it's not set
+//	@receiver s
+//	@return *big.Int
+//	@return error
+func (s *Schedule) Supply() (*big.Int, error) {
+	if s.MaxSupply == "" {
+		return nil, nil
+	}
+	supply, ok := new(big.Int).SetString(s.MaxSupply, 10)
+	if !ok || supply.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid max supply %s", s.MaxSupply)
+	}
+	return supply, nil
+}
+
+// Validate
+//
+//	@Description: check the start and the stop conditions
+//	@receiver s
+//	@return error
+func (s *Schedule) Validate() error {
+	if s.StartBlock > 0 && s.StartTime > 0 {
+		return errors.New("set either the start block or the start time")
+	}
+	if s.StartTime < 0 {
+		return errors.New("start time should be a unix time")
+	}
+	if s.EndBlock > 0 && s.EndBlock < s.StartBlock {
+		return fmt.Errorf("end block %d is before the start block %d", s.EndBlock, s.StartBlock)
+	}
+	if s.PreSign < 0 {
+		return errors.New("pre-signed inscriptions can't be negative")
+	}
+	if _, err := s.Supply(); err != nil {
+		return err
+	}
+	if s.MaxSupply != "" && s.SupplyMethod == "" {
+		return errors.New("max supply needs the supply method")
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package config
+
+import "testing"
+
+func TestScheduleValidate(t *testing.T) {
+	valid := []Schedule{
+		NewSchedule(),
+		{StartBlock: 100, EndBlock: 120},
+		{StartTime: 1700000000, MaxSupply: "21000000", SupplyMethod: DefaultSupplyMethod},
+	}
+	for _, schedule := range valid {
+		if err := schedule.Validate(); err != nil {
+			t.Fatalf("%+v: %s", schedule, err)
+		}
+	}
+
+	invalid := []Schedule{
+		{StartBlock: 100, StartTime: 1700000000},
+		{StartBlock: 100, EndBlock: 99},
+		{MaxSupply: "1e6", SupplyMethod: DefaultSupplyMethod},
+		{MaxSupply: "0", SupplyMethod: DefaultSupplyMethod},
+		{MaxSupply: "100"},
+		{PreSign: -1},
+	}
+	for _, schedule := range invalid {
+		if err := schedule.Validate(); err == nil {
+			t.Fatalf("%+v should be invalid", schedule)
+		}
+	}
+
+	supply, err := (&Schedule{MaxSupply: "21000000"}).Supply()
+	if err != nil || supply.Int64() != 21000000 {
+		t.Fatalf("unexpected supply %v, %v", supply, err)
+	}
+}
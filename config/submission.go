@@ -0,0 +1,54 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	SubmitterPublic  = "public"  // eth_sendRawTransaction to the rpc nodes
+	SubmitterPrivate = "private" // eth_sendPrivateTransaction to the relay, kept out of the public mempool
+	SubmitterBundle  = "bundle"  // eth_sendBundle of each tx to the relay, resubmitted for the next blocks until mined
+
+	DefaultRelayBlocks = 25
+)
+
+type Submission struct {
+	Submitter   string `json:"submitter"`
+	RelayUrl    string `json:"relayUrl"`    // private and bundle submitters
+	RelayKey    string `json:"relayKey"`    // private key signing the requests to the relay, a new one every run when empty
+	RelayBlocks int    `json:"relayBlocks"` // blocks a private tx may wait for, or target blocks of a bundle
+}
+
+// NewSubmission
+//
+//	@Description: public submission with the default relay blocks
+//	@return Submission
+func NewSubmission() Submission {
+	return Submission{
+		Submitter:   SubmitterPublic,
+		RelayBlocks: DefaultRelayBlocks,
+	}
+}
+
+// Validate
+//
+//	@Description: check the submitter and the relay it needs
+//	@receiver s
+//	@return error
+func (s *Submission) Validate() error {
+	switch s.Submitter {
+	case SubmitterPublic, "":
+		return nil
+	case SubmitterPrivate, SubmitterBundle:
+		if s.RelayUrl == "" {
+			return fmt.Errorf("the %s submitter needs a relay url", s.Submitter)
+		}
+		if s.RelayBlocks <= 0 {
+			return errors.New("relay blocks should be positive")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown submitter %q", s.Submitter)
+	}
+}
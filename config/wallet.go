@@ -0,0 +1,139 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Wallet is one minting account of a key file
+type Wallet struct {
+	Key   string // hex private key or mnemonic
+	Times int
+	Delay int
+}
+
+type walletJSON struct {
+	Key   string `json:"key"`
+	Times *int   `json:"times"`
+	Delay *int   `json:"delay"`
+}
+
+// LoadWallets
+//
+//	@Description: read the wallets of the key file, the format follows the extension:
+//	.json is an array of keys or of {"key","times","delay"} objects, .csv has the columns key,times,delay
+//	and anything else holds one key per line. Empty lines and lines starting with # are skipped.
+//	@param path
+//	@param times used when the wallet has no times
+//	@param delay used when the wallet has no delay
+//	@return []*Wallet
+//	@return error
+func LoadWallets(path string, times, delay int) ([]*Wallet, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var wallets []*Wallet
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		wallets, err = parseJSONWallets(content, times, delay)
+	case ".csv":
+		wallets, err = parseCSVWallets(content, times, delay)
+	default:
+		wallets, err = parseLineWallets(content, times, delay)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(wallets) == 0 {
+		return nil, fmt.Errorf("no wallet in %s", path)
+	}
+	return wallets, nil
+}
+
+func parseJSONWallets(content []byte, times, delay int) ([]*Wallet, error) {
+	var keys []string
+	if err := json.Unmarshal(content, &keys); err == nil {
+		return parseLineWallets([]byte(strings.Join(keys, "\n")), times, delay)
+	}
+
+	var items []walletJSON
+	if err := json.Unmarshal(content, &items); err != nil {
+		return nil, err
+	}
+	wallets := make([]*Wallet, 0, len(items))
+	for i, item := range items {
+		if item.Key == "" {
+			return nil, fmt.Errorf("wallet %d has no key", i+1)
+		}
+		wallet := &Wallet{Key: item.Key, Times: times, Delay: delay}
+		if item.Times != nil {
+			wallet.Times = *item.Times
+		}
+		if item.Delay != nil {
+			wallet.Delay = *item.Delay
+		}
+		wallets = append(wallets, wallet)
+	}
+	return wallets, nil
+}
+
+func parseCSVWallets(content []byte, times, delay int) ([]*Wallet, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var wallets []*Wallet
+	for i, record := range records {
+		key := strings.TrimSpace(record[0])
+		if key == "" || (i == 0 && strings.EqualFold(key, "key")) {
+			continue
+		}
+		wallet := &Wallet{Key: key, Times: times, Delay: delay}
+		if wallet.Times, err = csvInt(record, 1, times); err != nil {
+			return nil, fmt.Errorf("line %d: invalid times: %w", i+1, err)
+		}
+		if wallet.Delay, err = csvInt(record, 2, delay); err != nil {
+			return nil, fmt.Errorf("line %d: invalid delay: %w", i+1, err)
+		}
+		wallets = append(wallets, wallet)
+	}
+	return wallets, nil
+}
+
+// csvInt returns the int of the column, def when the column is missing or empty
+func csvInt(record []string, column int, def int) (int, error) {
+	if len(record) <= column || strings.TrimSpace(record[column]) == "" {
+		return def, nil
+	}
+	return strconv.Atoi(strings.TrimSpace(record[column]))
+}
+
+func parseLineWallets(content []byte, times, delay int) ([]*Wallet, error) {
+	var wallets []*Wallet
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		key := strings.TrimSpace(scanner.Text())
+		if key == "" || strings.HasPrefix(key, "#") {
+			continue
+		}
+		wallets = append(wallets, &Wallet{Key: key, Times: times, Delay: delay})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.New("read key file failed: " + err.Error())
+	}
+	return wallets, nil
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadWallets(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"keys.txt":  "# fleet\n0x01\n\n0x02\n",
+		"keys.csv":  "key,times,delay\n0x01,5,\n0x02,,3\n",
+		"keys.json": `[{"key":"0x01","times":5},{"key":"0x02","delay":3}]`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		wallets, err := LoadWallets(path, 1, 2)
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if len(wallets) != 2 || wallets[0].Key != "0x01" || wallets[1].Key != "0x02" {
+			t.Fatalf("%s: unexpected wallets %+v", name, wallets)
+		}
+		if name == "keys.txt" {
+			continue
+		}
+		if wallets[0].Times != 5 || wallets[0].Delay != 2 || wallets[1].Times != 1 || wallets[1].Delay != 3 {
+			t.Fatalf("%s: times and delay %+v %+v", name, wallets[0], wallets[1])
+		}
+	}
+}
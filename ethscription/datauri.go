@@ -0,0 +1,202 @@
+package ethscription
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Prefix is the scheme every ethscription starts with
+const Prefix = "data:"
+
+// DefaultMimeType is the mimetype of a data URI without one
+const DefaultMimeType = "text/plain"
+
+// Param is a parameter of the media type, e.g. charset=utf-8
+type Param struct {
+	Key   string
+	Value string
+}
+
+// DataURI is the parsed form of data:[<mimetype>][;<key>=<value>]*[;base64],<data>
+type DataURI struct {
+	MimeType string // empty means text/plain
+	Params   []Param
+	Base64   bool
+	Data     string // as it is after the comma
+}
+
+// Text
+//
+//	@Description: data URI of the plain text, kept as it is the way ethscriptions are usually written
+//	@param text
+//	@return string
+func Text(text string) string {
+	return Prefix + "," + text
+}
+
+// JSON
+//
+//	@Description: data URI of the json document in its compact form
+//	@param raw json text
+//	@return string
+//	@return error
+func JSON(raw []byte) (string, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return "", fmt.Errorf("invalid json: %w", err)
+	}
+	return Prefix + "application/json," + buf.String(), nil
+}
+
+// Base64
+//
+//	@Description: data URI of the content base64 encoded, e.g. an image
+//	@param mimeType
+//	@param content
+//	@return string
+func Base64(mimeType string, content []byte) string {
+	return (&DataURI{MimeType: mimeType, Base64: true, Data: base64.StdEncoding.EncodeToString(content)}).String()
+}
+
+// Parse
+//
+//	@Description: validate the syntax of the data URI
+//	@param uri
+//	@return *DataURI
+//	@return error
+func Parse(uri string) (*DataURI, error) {
+	if !strings.HasPrefix(uri, Prefix) {
+		return nil, fmt.Errorf("the data URI doesn't start with %s", Prefix)
+	}
+	header, data, ok := strings.Cut(uri[len(Prefix):], ",")
+	if !ok {
+		return nil, errors.New("the data URI has no comma before its data")
+	}
+
+	d := &DataURI{Data: data}
+	parts := strings.Split(header, ";")
+	if parts[0] != "" {
+		mimeType := strings.ToLower(parts[0])
+		kind, subtype, ok := strings.Cut(mimeType, "/")
+		if !ok || !isToken(kind) || !isToken(subtype) {
+			return nil, fmt.Errorf("invalid mimetype %q", parts[0])
+		}
+		d.MimeType = mimeType
+	}
+	for i, part := range parts[1:] {
+		if part == "base64" && i == len(parts)-2 {
+			d.Base64 = true
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || !isToken(key) || value == "" {
+			return nil, fmt.Errorf("invalid parameter %q", part)
+		}
+		d.Params = append(d.Params, Param{Key: strings.ToLower(key), Value: value})
+	}
+	if len(d.Params) > 0 && d.MimeType == "" {
+		// data:;charset=utf-8,... is the text/plain of RFC 2397
+		d.MimeType = DefaultMimeType
+	}
+
+	if d.Base64 {
+		if _, err := d.Content(); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// Validate
+//
+//	@Description: whether the data URI is valid
+//	@param uri
+//	@return error
+func Validate(uri string) error {
+	_, err := Parse(uri)
+	return err
+}
+
+// Content
+//
+//	@Description: the decoded data
+//	@receiver d
+//	@return []byte
+//	@return error
+func (d *DataURI) Content() ([]byte, error) {
+	if d.Base64 {
+		content, err := base64.StdEncoding.DecodeString(d.Data)
+		if err != nil {
+			// the padding is often left out
+			if content, err = base64.RawStdEncoding.DecodeString(d.Data); err != nil {
+				return nil, fmt.Errorf("invalid base64 data: %w", err)
+			}
+		}
+		return content, nil
+	}
+	content, err := url.PathUnescape(d.Data)
+	if err != nil {
+		// a plain % of a text is kept as it is
+		return []byte(d.Data), nil
+	}
+	return []byte(content), nil
+}
+
+// Charset
+//
+//	@Description: the charset parameter, empty when there is none
+//	@receiver d
+//	@return string
+func (d *DataURI) Charset() string {
+	for _, param := range d.Params {
+		if param.Key == "charset" {
+			return param.Value
+		}
+	}
+	return ""
+}
+
+func (d *DataURI) String() string {
+	var b strings.Builder
+	b.WriteString(Prefix)
+	b.WriteString(d.MimeType)
+	for _, param := range d.Params {
+		b.WriteString(";" + param.Key + "=" + param.Value)
+	}
+	if d.Base64 {
+		b.WriteString(";base64")
+	}
+	b.WriteString(",")
+	b.WriteString(d.Data)
+	return b.String()
+}
+
+// SHA256
+//
+//	@Description: hash of the whole data URI, an ethscription only counts when no earlier one has the same hash
+//	@param uri
+//	@return string 0x prefixed hex
+func SHA256(uri string) string {
+	sum := sha256.Sum256([]byte(uri))
+	return "0x" + hex.EncodeToString(sum[:])
+}
+
+// isToken reports whether s is a non empty token of RFC 2045
+func isToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range []byte(s) {
+		if c <= ' ' || c >= 0x7f || strings.IndexByte(`()<>@,;:\"/[]?=`, c) >= 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+package ethscription
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	valid := map[string]string{
+		"data:,hello world":                   "hello world",
+		"data:text/plain;charset=utf-8,hi%21": "hi!",
+		"data:image/png;base64,iVBORw0KGgo=":  "\x89PNG\r\n\x1a\n",
+		"data:;base64,aGk":                    "hi",
+		`data:,{"p":"erc-20","op":"mint"}`:    `{"p":"erc-20","op":"mint"}`,
+		"data:,100%":                          "100%",
+	}
+	for uri, want := range valid {
+		d, err := Parse(uri)
+		if err != nil {
+			t.Fatalf("%s: %s", uri, err)
+		}
+		content, err := d.Content()
+		if err != nil || string(content) != want {
+			t.Fatalf("%s: content %q %v", uri, content, err)
+		}
+		if d.String() != uri && uri != "data:text/plain;charset=utf-8,hi%21" {
+			t.Fatalf("%s: string %s", uri, d.String())
+		}
+	}
+
+	invalid := []string{
+		"hello",
+		"data:text/plain",
+		"data:image,abc",
+		"data:image/png;base64,***",
+		"data:text/plain;charset,abc",
+		"data:image/p ng,abc",
+	}
+	for _, uri := range invalid {
+		if _, err := Parse(uri); err == nil {
+			t.Fatalf("%s: expect an error", uri)
+		}
+	}
+}
+
+func TestBuild(t *testing.T) {
+	uri, err := JSON([]byte("{\n  \"p\": \"erc-20\"\n}"))
+	if err != nil || uri != `data:application/json,{"p":"erc-20"}` {
+		t.Fatalf("json %s %v", uri, err)
+	}
+	if uri := Base64("image/svg+xml", []byte("<svg/>")); uri != "data:image/svg+xml;base64,PHN2Zy8+" {
+		t.Fatalf("base64 %s", uri)
+	}
+	// sha256 of "data:,hello"
+	if sha := SHA256(Text("hello")); sha != "0x06c84f230c1ff90bd6aa50ec631cf556ca2a6da0cd6ff07ce61acecd5afb2012" {
+		t.Fatalf("sha256 %s", sha)
+	}
+}
@@ -0,0 +1,38 @@
+package ethscription
+
+import (
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// File
+//
+//	@Description: data URI of the file base64 encoded, the mimetype comes from the extension or else the content
+//	@param path
+//	@return string
+//	@return error
+func File(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return Base64(DetectMimeType(path, content), content), nil
+}
+
+// DetectMimeType
+//
+//	@Description: mimetype of the file without its parameters
+//	@param path
+//	@param content
+//	@return string
+func DetectMimeType(path string, content []byte) string {
+	mimeType := mime.TypeByExtension(strings.ToLower(filepath.Ext(path)))
+	if mimeType == "" {
+		mimeType = http.DetectContentType(content)
+	}
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	return strings.TrimSpace(mimeType)
+}
@@ -0,0 +1,37 @@
+package ethscription
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.svg":  "image/svg+xml",
+		"a.json": "application/json",
+		"a":      "image/png",
+	}
+	for name, want := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("\x89PNG\r\n\x1a\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		uri, err := File(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(uri, "data:"+want+";base64,") {
+			t.Fatalf("%s: %s", name, uri)
+		}
+		if err := Validate(uri); err != nil {
+			t.Fatalf("%s: %s", uri, err)
+		}
+	}
+
+	if gas := IntrinsicGas([]byte{0, 1, 2}); gas != 21000+4+16*2 {
+		t.Fatalf("intrinsic gas %d", gas)
+	}
+}
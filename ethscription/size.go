@@ -0,0 +1,28 @@
+package ethscription
+
+// MaxDataSize is the largest calldata sent, the tx pools drop txs over 128KB and the rest of the tx needs room
+const MaxDataSize = 128*1024 - 1024
+
+// the gas of a tx before it runs
+const (
+	txGas            = 21000
+	txDataZeroGas    = 4
+	txDataNonZeroGas = 16
+)
+
+// IntrinsicGas
+//
+//	@Description: the gas a tx carrying the calldata costs at least, a lower gas limit is rejected
+//	@param data
+//	@return uint64
+func IntrinsicGas(data []byte) uint64 {
+	gas := uint64(txGas)
+	for _, b := range data {
+		if b == 0 {
+			gas += txDataZeroGas
+		} else {
+			gas += txDataNonZeroGas
+		}
+	}
+	return gas
+}
@@ -0,0 +1,185 @@
+package ethscription
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// the operations of the token protocols
+const (
+	OpDeploy   = "deploy"
+	OpMint     = "mint"
+	OpTransfer = "transfer"
+)
+
+// DefaultProtocol is the protocol of the ethscriptions tokens
+const DefaultProtocol = "erc-20"
+
+// Protocols are the token protocols known to the indexers
+var Protocols = []string{"erc-20", "brc-20", "asc-20", "bsc-20", "prc-20"}
+
+// maxTickLength is the longest tick accepted, the indexers mostly take 4 characters
+const maxTickLength = 16
+
+var amountPattern = regexp.MustCompile(`^(0|[1-9][0-9]*)(\.[0-9]+)?$`)
+
+// Operation is a token operation inscribed as json, the fields are written in the order of the struct
+type Operation struct {
+	P    string `json:"p"`
+	Op   string `json:"op"`
+	Tick string `json:"tick"`
+	Id   string `json:"id,omitempty"`  // serial of the mint, required by some deploys
+	Max  string `json:"max,omitempty"` // max supply of the deploy
+	Lim  string `json:"lim,omitempty"` // limit of each mint of the deploy
+	Amt  string `json:"amt,omitempty"` // amount of the mint or transfer
+}
+
+// Deploy
+//
+//	@Description:
+//	@param protocol
+//	@param tick
+//	@param max max supply
+//	@param lim limit of each mint
+//	@return *Operation
+func Deploy(protocol, tick, max, lim string) *Operation {
+	return &Operation{P: protocol, Op: OpDeploy, Tick: tick, Max: max, Lim: lim}
+}
+
+// Mint
+//
+//	@Description:
+//	@param protocol
+//	@param tick
+//	@param amt
+//	@return *Operation
+func Mint(protocol, tick, amt string) *Operation {
+	return &Operation{P: protocol, Op: OpMint, Tick: tick, Amt: amt}
+}
+
+// Transfer
+//
+//	@Description:
+//	@param protocol
+//	@param tick
+//	@param amt
+//	@return *Operation
+func Transfer(protocol, tick, amt string) *Operation {
+	return &Operation{P: protocol, Op: OpTransfer, Tick: tick, Amt: amt}
+}
+
+// Validate
+//
+//	@Description: check the fields the operation needs and the ones it mustn't have
+//	@receiver o
+//	@return error
+func (o *Operation) Validate() error {
+	if !isProtocol(o.P) {
+		return fmt.Errorf("unknown protocol %q, expect one of %s", o.P, strings.Join(Protocols, ", "))
+	}
+	if o.Tick == "" || len([]rune(o.Tick)) > maxTickLength || strings.ContainsAny(o.Tick, " \t\r\n\"\\") {
+		return fmt.Errorf("invalid tick %q", o.Tick)
+	}
+
+	switch o.Op {
+	case OpDeploy:
+		if o.Amt != "" || o.Id != "" {
+			return errors.New("deploy has no amt or id")
+		}
+		if err := checkAmount("max", o.Max); err != nil {
+			return err
+		}
+		if o.Lim != "" {
+			if err := checkAmount("lim", o.Lim); err != nil {
+				return err
+			}
+			if compareAmount(o.Lim, o.Max) > 0 {
+				return fmt.Errorf("lim %s is above max %s", o.Lim, o.Max)
+			}
+		}
+	case OpMint, OpTransfer:
+		if o.Max != "" || o.Lim != "" {
+			return fmt.Errorf("%s has no max or lim", o.Op)
+		}
+		if o.Id != "" && o.Op == OpTransfer {
+			return errors.New("transfer has no id")
+		}
+		if err := checkAmount("amt", o.Amt); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown op %q, expect deploy, mint or transfer", o.Op)
+	}
+	return nil
+}
+
+// JSON
+//
+//	@Description: the canonical json of the operation, compact with the fields in a fixed order
+//	@receiver o
+//	@return string
+//	@return error
+func (o *Operation) JSON() (string, error) {
+	if err := o.Validate(); err != nil {
+		return "", err
+	}
+	// the indexers compare the text, < > & stay as they are instead of \u003c
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(o); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// URI
+//
+//	@Description: the data URI the indexers read, e.g. data:,{"p":"erc-20","op":"mint","tick":"eths","amt":"1000"}
+//	@receiver o
+//	@return string
+//	@return error
+func (o *Operation) URI() (string, error) {
+	raw, err := o.JSON()
+	if err != nil {
+		return "", err
+	}
+	return Text(raw), nil
+}
+
+func isProtocol(p string) bool {
+	for _, protocol := range Protocols {
+		if p == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+// checkAmount accepts positive decimal numbers without sign, exponent or leading zeros
+func checkAmount(name, amount string) error {
+	if !amountPattern.MatchString(amount) || strings.Trim(amount, "0.") == "" {
+		return fmt.Errorf("invalid %s %q, expect a positive number", name, amount)
+	}
+	return nil
+}
+
+// compareAmount compares two amounts accepted by checkAmount
+func compareAmount(a, b string) int {
+	aInt, aFrac, _ := strings.Cut(a, ".")
+	bInt, bFrac, _ := strings.Cut(b, ".")
+	if len(aInt) != len(bInt) {
+		if len(aInt) < len(bInt) {
+			return -1
+		}
+		return 1
+	}
+	if c := strings.Compare(aInt, bInt); c != 0 {
+		return c
+	}
+	return strings.Compare(strings.TrimRight(aFrac, "0"), strings.TrimRight(bFrac, "0"))
+}
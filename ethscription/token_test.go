@@ -0,0 +1,41 @@
+package ethscription
+
+import "testing"
+
+func TestOperation(t *testing.T) {
+	valid := map[*Operation]string{
+		Mint("erc-20", "eths", "1000"):                                `data:,{"p":"erc-20","op":"mint","tick":"eths","amt":"1000"}`,
+		Deploy("brc-20", "ordi", "21000000", "1000"):                  `data:,{"p":"brc-20","op":"deploy","tick":"ordi","max":"21000000","lim":"1000"}`,
+		Transfer("erc-20", "eths", "0.5"):                             `data:,{"p":"erc-20","op":"transfer","tick":"eths","amt":"0.5"}`,
+		{P: "erc-20", Op: OpMint, Tick: "eths", Id: "7", Amt: "1000"}: `data:,{"p":"erc-20","op":"mint","tick":"eths","id":"7","amt":"1000"}`,
+		Mint("erc-20", "<&>", "1"):                                    `data:,{"p":"erc-20","op":"mint","tick":"<&>","amt":"1"}`,
+	}
+	for op, want := range valid {
+		uri, err := op.URI()
+		if err != nil || uri != want {
+			t.Fatalf("%+v: %s %v", op, uri, err)
+		}
+		if err := Validate(uri); err != nil {
+			t.Fatalf("%s: %s", uri, err)
+		}
+	}
+
+	invalid := []*Operation{
+		Mint("erc20", "eths", "1000"),
+		Mint("erc-20", "", "1000"),
+		Mint("erc-20", "et hs", "1000"),
+		Mint("erc-20", "eths", "01000"),
+		Mint("erc-20", "eths", "-1"),
+		Mint("erc-20", "eths", "0"),
+		Mint("erc-20", "eths", "1e3"),
+		Deploy("erc-20", "eths", "1000", "1001"),
+		Deploy("erc-20", "eths", "", "1"),
+		{P: "erc-20", Op: OpMint, Tick: "eths", Max: "1", Amt: "1"},
+		{P: "erc-20", Op: "burn", Tick: "eths", Amt: "1"},
+	}
+	for _, op := range invalid {
+		if _, err := op.URI(); err == nil {
+			t.Fatalf("%+v: expect an error", op)
+		}
+	}
+}
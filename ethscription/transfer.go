@@ -0,0 +1,49 @@
+package ethscription
+
+import (
+	"errors"
+	"fmt"
+	"inscription/chain/util"
+	"strings"
+)
+
+// ValidateID
+//
+//	@Description: an ethscription id is the hash of the tx which created it, 32 bytes of hex
+//	@param id
+//	@return error
+func ValidateID(id string) error {
+	raw, err := util.HexDecodeString(id)
+	if err != nil || !strings.HasPrefix(id, "0x") || len(id) != 2+2*util.HashLength || len(raw) != util.HashLength {
+		return fmt.Errorf("invalid ethscription id %s, expect a 0x prefixed 32 bytes hash", id)
+	}
+	return nil
+}
+
+// TransferData
+//
+//	@Description: calldata moving the ethscriptions to the recipient of the tx: the id of one ethscription,
+//	or the ids of several ones one after the other as the bulk transfer of ESIP-5
+//	@param ids
+//	@return string hex data
+//	@return error
+func TransferData(ids []string) (string, error) {
+	if len(ids) == 0 {
+		return "", errors.New("no ethscription id")
+	}
+	seen := make(map[string]bool, len(ids))
+	var b strings.Builder
+	b.WriteString("0x")
+	for _, id := range ids {
+		if err := ValidateID(id); err != nil {
+			return "", err
+		}
+		id = strings.ToLower(id[2:])
+		if seen[id] {
+			return "", fmt.Errorf("ethscription 0x%s is transferred twice", id)
+		}
+		seen[id] = true
+		b.WriteString(id)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,33 @@
+package ethscription
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTransferData(t *testing.T) {
+	a := "0x" + strings.Repeat("ab", 32)
+	b := "0x" + strings.Repeat("CD", 32)
+
+	data, err := TransferData([]string{a})
+	if err != nil || data != a {
+		t.Fatalf("single %s %v", data, err)
+	}
+	data, err = TransferData([]string{a, b})
+	if err != nil || data != a+strings.Repeat("cd", 32) {
+		t.Fatalf("bulk %s %v", data, err)
+	}
+
+	for _, ids := range [][]string{
+		nil,
+		{a, a},
+		{a[:64]},
+		{strings.Repeat("ab", 32)},
+		{a + "ab"},
+		{"0x" + strings.Repeat("zz", 32)},
+	} {
+		if _, err := TransferData(ids); err == nil {
+			t.Fatalf("%v: expect an error", ids)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package feature
+
+import (
+	"inscription/chain/eth/core"
+	"inscription/ethscription"
+)
+
+// TransferEthscriptions
+//
+//	@Description: move the ethscriptions of the signer to the recipient, one id as calldata or several ones as an ESIP-5 bulk transfer
+//	@receiver t
+//	@param signer
+//	@param gasPrice gas price of legacy tx, max fee per gas of 1559 tx
+//	@param gasLimit
+//	@param maxPriorityFeePerGas empty for legacy tx
+//	@param to the new owner
+//	@param ids hashes of the txs which created the ethscriptions
+//	@return *core.BuildTxResult
+//	@return error
+func (t *Token) TransferEthscriptions(signer core.Signer, gasPrice, gasLimit, maxPriorityFeePerGas, to string, ids []string) (*core.BuildTxResult, error) {
+	data, err := ethscription.TransferData(ids)
+	if err != nil {
+		return nil, err
+	}
+	return t.TransferBySigner(signer, gasPrice, gasLimit, maxPriorityFeePerGas, "0", to, data)
+}
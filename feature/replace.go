@@ -0,0 +1,94 @@
+package feature
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/core/types"
+	"inscription/chain/eth/core"
+	"inscription/chain/util"
+	"inscription/config"
+	"math/big"
+	"strconv"
+)
+
+type Replacement struct {
+	BumpPercent          int    // fee increase over the pending tx, at least config.MinReplaceBump
+	Cancel               bool   // replace with a 0 value self transfer
+	GasPrice             string // optional floor of the new gas price or max fee per gas, wei
+	MaxPriorityFeePerGas string // optional floor of the new max priority fee per gas, wei
+}
+
+// ReplaceTx
+//
+//	@Description: rebuild the pending tx with the same nonce and a higher fee, speed up or cancel it
+//	@receiver t
+//	@param signer the sender of the pending tx
+//	@param hash hash of the pending tx
+//	@param replacement
+//	@return *core.BuildTxResult
+//	@return error
+func (t *Token) ReplaceTx(signer core.Signer, hash string, replacement *Replacement) (*core.BuildTxResult, error) {
+	if t.proxy == nil {
+		return nil, errors.New("the proxy node is empty")
+	}
+	pending, err := t.proxy.PendingTransaction(hash)
+	if err != nil {
+		return nil, err
+	}
+	from, err := t.proxy.Sender(pending)
+	if err != nil {
+		return nil, err
+	}
+	address := signer.Address()
+	if from != address {
+		return nil, fmt.Errorf("the transaction is sent by %s instead of %s", from, address)
+	}
+
+	bump := replacement.BumpPercent
+	gasPrice, err := bumpFee(pending.GasFeeCap(), bump, replacement.GasPrice)
+	if err != nil {
+		return nil, err
+	}
+	maxPriorityFeePerGas := ""
+	if pending.Type() == types.DynamicFeeTxType {
+		tip, err := bumpFee(pending.GasTipCap(), bump, replacement.MaxPriorityFeePerGas)
+		if err != nil {
+			return nil, err
+		}
+		maxPriorityFeePerGas = tip.String()
+	}
+
+	nonce := strconv.FormatUint(pending.Nonce(), 10)
+	var tx *core.Transaction
+	if replacement.Cancel {
+		tx = core.NewTransaction(nonce, gasPrice.String(), config.DefaultEthGasLimit, maxPriorityFeePerGas, address, "0", "")
+	} else {
+		to := ""
+		if pending.To() != nil {
+			to = pending.To().Hex()
+		}
+		tx = core.NewTransaction(nonce, gasPrice.String(), strconv.FormatUint(pending.Gas(), 10), maxPriorityFeePerGas, to, pending.Value().String(), util.HexEncodeToString(pending.Data()))
+	}
+	return t.SendTransaction(signer, tx)
+}
+
+// bumpFee returns max(fee * (100 + percent) / 100 rounded up, floor), the percent is at least config.MinReplaceBump
+func bumpFee(fee *big.Int, percent int, floor string) (*big.Int, error) {
+	if percent < config.MinReplaceBump {
+		percent = config.MinReplaceBump
+	}
+	bumped := new(big.Int).Mul(fee, big.NewInt(int64(100+percent)))
+	bumped.Add(bumped, big.NewInt(99))
+	bumped.Div(bumped, big.NewInt(100))
+	if floor == "" {
+		return bumped, nil
+	}
+	least, ok := new(big.Int).SetString(floor, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid fee %s", floor)
+	}
+	if least.Cmp(bumped) > 0 {
+		return least, nil
+	}
+	return bumped, nil
+}
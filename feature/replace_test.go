@@ -0,0 +1,47 @@
+package feature
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestBumpFee(t *testing.T) {
+	tests := []struct {
+		name    string
+		fee     int64
+		percent int
+		floor   string
+		want    int64
+		wantErr bool
+	}{
+		{"bump", 100, 20, "", 120, false},
+		{"rounded up", 101, 10, "", 112, false},
+		{"below the minimum bump", 100, 5, "", 110, false},
+		{"no bump", 100, 0, "", 110, false},
+		{"1 wei still goes up", 1, 10, "", 2, false},
+		{"floor above the bump", 100, 10, "150", 150, false},
+		{"floor below the bump", 100, 10, "105", 110, false},
+		{"invalid floor", 100, 10, "1.5", 0, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fee := big.NewInt(test.fee)
+			got, err := bumpFee(fee, test.percent, test.floor)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expect an error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Int64() != test.want {
+				t.Fatalf("bumped to %s, want %d", got, test.want)
+			}
+			if fee.Int64() != test.fee {
+				t.Fatalf("the fee of the pending tx changed to %s", fee)
+			}
+		})
+	}
+}
@@ -4,9 +4,9 @@ import (
 	"context"
 	"errors"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
 	"inscription/chain/eth/core"
 	"inscription/chain/util"
+	"strconv"
 	"time"
 )
 
@@ -38,32 +38,107 @@ func (t *Token) BalanceOf(address string) (balance string, err error) {
 }
 
 func (t *Token) Transfer(privateKey, gasPrice, gasLimit, maxPriorityFeePerGas, value, to, data string) (hash string, err error) {
+	txSign, err := t.TransferTx(privateKey, gasPrice, gasLimit, maxPriorityFeePerGas, value, to, data)
+	if err != nil {
+		return "", err
+	}
+	return txSign.TxHex, nil
+}
+
+// TransferTx
+//
+//	@Description: same as Transfer, returns the signed tx
+//	@receiver t
+//	@return *core.BuildTxResult
+//	@return error
+func (t *Token) TransferTx(privateKey, gasPrice, gasLimit, maxPriorityFeePerGas, value, to, data string) (*core.BuildTxResult, error) {
+	if gasPrice == "" || gasLimit == "" || to == "" || value == "" {
+		return nil, errors.New("param is error")
+	}
+	privateKeyECDSA, err := core.PrivateKeyToECDSA(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return t.TransferBySigner(core.NewKeySigner(privateKeyECDSA), gasPrice, gasLimit, maxPriorityFeePerGas, value, to, data)
+}
+
+// TransferBySigner
+//
+//	@Description: same as TransferTx, signed by the signer
+//	@receiver t
+//	@return *core.BuildTxResult
+//	@return error
+func (t *Token) TransferBySigner(signer core.Signer, gasPrice, gasLimit, maxPriorityFeePerGas, value, to, data string) (*core.BuildTxResult, error) {
 	if gasPrice == "" || gasLimit == "" || to == "" || value == "" {
-		return "", errors.New("param is error")
+		return nil, errors.New("param is error")
 	}
 	tx := core.NewTransaction("", gasPrice, gasLimit, maxPriorityFeePerGas, to, value, data)
+	return t.SendTransaction(signer, tx)
+}
 
-	priData, err := util.HexDecodeString(privateKey)
-	if err != nil {
-		return "", err
+// SendTransaction
+//
+//	@Description: sign and send the tx, an empty nonce is taken from the local nonce manager of the proxy
+//	@receiver t
+//	@param signer
+//	@param tx
+//	@return *core.BuildTxResult
+//	@return error
+func (t *Token) SendTransaction(signer core.Signer, tx *core.Transaction) (*core.BuildTxResult, error) {
+	if t.proxy == nil {
+		return nil, errors.New("the proxy node is empty")
 	}
-	privateKeyECDSA, err := crypto.ToECDSA(priData)
-	address := crypto.PubkeyToAddress(privateKeyECDSA.PublicKey).Hex()
+	address := signer.Address()
 
-	//get no sign tx
-	txUnSign, err := t.proxy.BuildTxUnSign(address, tx)
-	if err != nil {
-		return "", err
+	managed := tx.Nonce == ""
+	var nonce uint64
+	if managed {
+		var err error
+		nonce, err = t.proxy.Nonces().Next(address)
+		if err != nil {
+			return nil, err
+		}
+		tx.Nonce = strconv.FormatUint(nonce, 10)
 	}
 
-	//tx sign
-	txSign, err := t.proxy.BuildTxSign(privateKeyECDSA, txUnSign)
+	txSign, err := t.signAndSend(signer, tx)
+	if err != nil && managed {
+		t.proxy.Nonces().Failed(address, nonce, txSign != nil)
+	}
+	return txSign, err
+}
+
+// signAndSend returns the signed tx once it's sent, the error of a tx which failed before it is without it
+func (t *Token) signAndSend(signer core.Signer, tx *core.Transaction) (*core.BuildTxResult, error) {
+	txSign, err := t.SignTransaction(signer, tx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	//send tx
-	return txSign.TxHex, t.proxy.SendTx(txSign.SignedTx)
+	return txSign, t.proxy.SendTx(txSign.SignedTx)
+}
+
+// SignTransaction
+//
+//	@Description: sign the tx without sending it, an empty nonce is the pending nonce of the node
+//	@receiver t
+//	@param signer
+//	@param tx
+//	@return *core.BuildTxResult
+//	@return error
+func (t *Token) SignTransaction(signer core.Signer, tx *core.Transaction) (*core.BuildTxResult, error) {
+	if t.proxy == nil {
+		return nil, errors.New("the proxy node is empty")
+	}
+	//get no sign tx
+	txUnSign, err := t.proxy.BuildTxUnSign(signer.Address(), tx)
+	if err != nil {
+		return nil, err
+	}
+
+	//tx sign
+	return t.proxy.SignTx(signer, txUnSign)
 }
 
 func (t *Token) EstimateGasLimit(fromAddress, receiverAddress, gasPrice, amount string, data []byte) (string, error) {
@@ -0,0 +1,323 @@
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"inscription/chain/eth/core"
+	"inscription/chain/util"
+	"inscription/config"
+	"os"
+	"sync"
+	"time"
+)
+
+// the kinds of the journal entries
+const (
+	KindRun     = "run"     // a new run starts with its config
+	KindResume  = "resume"  // the last run continues
+	KindJob     = "job"     // the number of inscriptions of an account in the run
+	KindTx      = "tx"      // a tx is sent or failed to be sent
+	KindReceipt = "receipt" // the final status of a tx after waiting for its receipt
+)
+
+// StatusSent and StatusFailed complete the statuses of core.TxStatus
+const (
+	StatusSent   = "sent"
+	StatusFailed = "failed"
+)
+
+// Entry is one line of the journal
+type Entry struct {
+	Time                 time.Time           `json:"time"`
+	Kind                 string              `json:"kind"`
+	Config               *config.Inscription `json:"config,omitempty"`
+	Account              string              `json:"account,omitempty"`
+	Times                int                 `json:"times,omitempty"`
+	Index                int                 `json:"index,omitempty"`
+	Item                 string              `json:"item,omitempty"`
+	Nonce                uint64              `json:"nonce,omitempty"`
+	Hash                 string              `json:"hash,omitempty"`
+	Replaced             []string            `json:"replaced,omitempty"`
+	RawTx                string              `json:"rawTx,omitempty"`
+	GasPrice             string              `json:"gasPrice,omitempty"`
+	MaxPriorityFeePerGas string              `json:"maxPriorityFeePerGas,omitempty"`
+	GasLimit             string              `json:"gasLimit,omitempty"`
+	Status               string              `json:"status,omitempty"`
+	Error                string              `json:"error,omitempty"`
+}
+
+// Journal appends the entries of the runs to a jsonl file, each entry is synced to disk
+type Journal struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+// Open
+//
+//	@Description: open the journal for appending, it's created when missing
+//	@param path
+//	@return *Journal
+//	@return error
+func Open(path string) (*Journal, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{file: file}, nil
+}
+
+func (j *Journal) Close() error {
+	return j.file.Close()
+}
+
+// Append
+//
+//	@Description: write the entry as one line
+//	@receiver j
+//	@param entry
+//	@return error
+func (j *Journal) Append(entry *Entry) error {
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	if _, err = j.file.Write(line); err != nil {
+		return err
+	}
+	return j.file.Sync()
+}
+
+// StartRun
+//
+//	@Description: record the config of a new run, the secrets are left out
+//	@receiver j
+//	@param ins
+//	@return error
+func (j *Journal) StartRun(ins *config.Inscription) error {
+	run := *ins
+	run.PrivateKey = ""
+	run.Mnemonic = ""
+	run.MnemonicPassphrase = ""
+	run.RelayKey = ""
+	return j.Append(&Entry{Kind: KindRun, Config: &run})
+}
+
+// Sent
+//
+//	@Description: record the ith inscription of the account sent as the signed tx
+//	@receiver j
+//	@param index
+//	@param txSign
+//	@return error
+func (j *Journal) Sent(index int, txSign *core.BuildTxResult) error {
+	return j.SentItem(index, "", txSign)
+}
+
+// SentItem
+//
+//	@Description: same as Sent for the named item of a drop
+//	@receiver j
+//	@param index
+//	@param item
+//	@param txSign
+//	@return error
+func (j *Journal) SentItem(index int, item string, txSign *core.BuildTxResult) error {
+	entry, err := txEntry(index, txSign)
+	if err != nil {
+		return err
+	}
+	entry.Item = item
+	return j.Append(entry)
+}
+
+// Failed
+//
+//	@Description: record the ith inscription of the account not sent
+//	@receiver j
+//	@param account
+//	@param index
+//	@param nonce
+//	@param txSign the signed tx when sending it failed, the node may have got it anyway
+//	@param sendErr
+//	@return error
+func (j *Journal) Failed(account string, index int, nonce uint64, txSign *core.BuildTxResult, sendErr error) error {
+	return j.FailedItem(account, index, "", nonce, txSign, sendErr)
+}
+
+// FailedItem
+//
+//	@Description: same as Failed for the named item of a drop
+//	@receiver j
+//	@return error
+func (j *Journal) FailedItem(account string, index int, item string, nonce uint64, txSign *core.BuildTxResult, sendErr error) error {
+	entry := &Entry{Kind: KindTx, Account: account, Index: index, Nonce: nonce}
+	if txSign != nil && txSign.SignedTx != nil {
+		var err error
+		if entry, err = txEntry(index, txSign); err != nil {
+			return err
+		}
+	}
+	entry.Item = item
+	entry.Status = StatusFailed
+	entry.Error = sendErr.Error()
+	return j.Append(entry)
+}
+
+// Replacement
+//
+//	@Description: record the tx sent with the nonce of the replaced ones, e.g. a speed up
+//	@receiver j
+//	@param txSign
+//	@param replaced hashes of the earlier txs with the same nonce
+//	@return error
+func (j *Journal) Replacement(txSign *core.BuildTxResult, replaced []string) error {
+	entry, err := txEntry(0, txSign)
+	if err != nil {
+		return err
+	}
+	entry.Replaced = replaced
+	return j.Append(entry)
+}
+
+func txEntry(index int, txSign *core.BuildTxResult) (*Entry, error) {
+	raw, err := txSign.SignedTx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	entry := &Entry{
+		Kind:     KindTx,
+		Account:  txSign.From,
+		Index:    index,
+		Nonce:    txSign.SignedTx.Nonce(),
+		Hash:     txSign.TxHex,
+		RawTx:    util.HexEncodeToString(raw),
+		GasPrice: txSign.SignedTx.GasFeeCap().String(),
+		GasLimit: fmt.Sprint(txSign.SignedTx.Gas()),
+		Status:   StatusSent,
+	}
+	if txSign.SignedTx.Type() != 0 {
+		entry.MaxPriorityFeePerGas = txSign.SignedTx.GasTipCap().String()
+	}
+	return entry, nil
+}
+
+// Job
+//
+//	@Description: record the number of inscriptions the account has to make in the run
+//	@receiver j
+//	@param account
+//	@param times
+//	@return error
+func (j *Journal) Job(account string, times int) error {
+	return j.Append(&Entry{Kind: KindJob, Account: account, Times: times})
+}
+
+// Resume
+//
+//	@Description: record that the last run continues
+//	@receiver j
+//	@return error
+func (j *Journal) Resume() error {
+	return j.Append(&Entry{Kind: KindResume})
+}
+
+// Receipts
+//
+//	@Description: record the status of the tracked txs
+//	@receiver j
+//	@param receipts
+//	@return error
+func (j *Journal) Receipts(receipts []*core.TxReceipt) error {
+	for _, receipt := range receipts {
+		err := j.Append(&Entry{
+			Kind:     KindReceipt,
+			Account:  receipt.From,
+			Nonce:    receipt.Nonce,
+			Hash:     receipt.Hash,
+			Replaced: receipt.Replaced,
+			Status:   string(receipt.Status),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Read
+//
+//	@Description: every entry of the journal, a broken last line is skipped
+//	@param path
+//	@return []*Entry
+//	@return error
+func Read(path string) ([]*Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []*Entry
+	var broken error
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		if broken != nil {
+			return nil, broken
+		}
+		entry := new(Entry)
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			// only the last line may be cut by a crash
+			broken = fmt.Errorf("line %d of %s: %w", line, path, err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// LastRun
+//
+//	@Description: the config and the entries of the last run, including the resumes of it
+//	@param entries
+//	@return *config.Inscription
+//	@return []*Entry
+//	@return error
+func LastRun(entries []*Entry) (*config.Inscription, []*Entry, error) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Kind == KindRun && entries[i].Config != nil {
+			return entries[i].Config, entries[i+1:], nil
+		}
+	}
+	return nil, nil, errors.New("no run in the journal")
+}
+
+// Jobs
+//
+//	@Description: the number of inscriptions of each account, the first job of an account in the entries wins
+//	@param entries
+//	@return map[string]int
+func Jobs(entries []*Entry) map[string]int {
+	jobs := make(map[string]int)
+	for _, entry := range entries {
+		if entry.Kind != KindJob {
+			continue
+		}
+		if _, ok := jobs[entry.Account]; !ok {
+			jobs[entry.Account] = entry.Times
+		}
+	}
+	return jobs
+}
@@ -0,0 +1,61 @@
+package journal
+
+import (
+	"inscription/config"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadLastRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	j, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := config.NewInscription()
+	first.Times = 3
+	second := config.NewInscription()
+	second.Times = 5
+	second.PrivateKey = "0x01"
+	second.RelayKey = "0x02"
+	for _, entry := range []func() error{
+		func() error { return j.StartRun(first) },
+		func() error { return j.Job("0xa", 3) },
+		func() error { return j.StartRun(second) },
+		func() error { return j.Job("0xb", 5) },
+		func() error { return j.Resume() },
+		func() error { return j.Job("0xb", 2) },
+	} {
+		if err := entry(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	j.Close()
+
+	// a crash cuts the last line
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"kind":"tx","acc`)
+	file.Close()
+
+	entries, err := Read(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, runEntries, err := LastRun(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Times != 5 || run.PrivateKey != "" || run.RelayKey != "" {
+		t.Fatalf("unexpected run config %+v", run)
+	}
+	if len(runEntries) != 3 {
+		t.Fatalf("expect 3 entries of the run, got %d", len(runEntries))
+	}
+	if jobs := Jobs(runEntries); len(jobs) != 1 || jobs["0xb"] != 5 {
+		t.Fatalf("unexpected jobs %v", jobs)
+	}
+}
@@ -0,0 +1,106 @@
+package payload
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"text/template"
+	"time"
+)
+
+// maxRandom is the longest random string of a payload
+const maxRandom = 256
+
+// Vars are the values a payload template renders for every inscription
+type Vars struct {
+	Index     int    // the ith inscription of the account, from 1
+	Counter   int    // the ith inscription of the run across every account, from 1
+	Address   string // the account sending the inscription
+	Timestamp int64  // unix time of the rendering, second
+}
+
+// Random
+//
+//	@Description: n random hex characters, e.g. a salt
+//	@receiver v
+//	@param n
+//	@return string
+//	@return error
+func (v Vars) Random(n int) (string, error) {
+	if n <= 0 || n > maxRandom {
+		return "", fmt.Errorf("random length %d out of 1-%d", n, maxRandom)
+	}
+	buf := make([]byte, (n+1)/2)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf)[:n], nil
+}
+
+// Template renders a different payload for every inscription, e.g. data:,{"id":"{{.Index}}","salt":"{{.Random 8}}"}
+type Template struct {
+	text    string
+	tmpl    *template.Template
+	counter atomic.Int64
+}
+
+// IsTemplate
+//
+//	@Description: whether the text has any action to render
+//	@param text
+//	@return bool
+func IsTemplate(text string) bool {
+	return strings.Contains(text, "{{")
+}
+
+// Parse
+//
+//	@Description:
+//	@param text
+//	@return *Template
+//	@return error
+func Parse(text string) (*Template, error) {
+	tmpl, err := template.New("payload").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payload template: %w", err)
+	}
+	return &Template{text: text, tmpl: tmpl}, nil
+}
+
+func (t *Template) String() string {
+	return t.text
+}
+
+// Render
+//
+//	@Description: the payload of the vars
+//	@receiver t
+//	@param vars
+//	@return string
+//	@return error
+func (t *Template) Render(vars Vars) (string, error) {
+	var b strings.Builder
+	if err := t.tmpl.Execute(&b, vars); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// Next
+//
+//	@Description: render the payload of the next inscription of the run
+//	@receiver t
+//	@param index the ith inscription of the account
+//	@param address
+//	@return string
+//	@return error
+func (t *Template) Next(index int, address string) (string, error) {
+	return t.Render(Vars{
+		Index:     index,
+		Counter:   int(t.counter.Add(1)),
+		Address:   address,
+		Timestamp: time.Now().Unix(),
+	})
+}
@@ -0,0 +1,35 @@
+package payload
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestTemplate(t *testing.T) {
+	tmpl, err := Parse(`data:,{"id":"{{.Index}}","n":"{{.Counter}}","to":"{{.Address}}","salt":"{{.Random 8}}"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pattern := regexp.MustCompile(`^data:,\{"id":"3","n":"(\d)","to":"0xabc","salt":"[0-9a-f]{8}"\}$`)
+	for want := 1; want <= 2; want++ {
+		text, err := tmpl.Next(3, "0xabc")
+		if err != nil {
+			t.Fatal(err)
+		}
+		match := pattern.FindStringSubmatch(text)
+		if match == nil || match[1] != string(rune('0'+want)) {
+			t.Fatalf("unexpected payload %s", text)
+		}
+	}
+
+	if _, err := Parse("{{.Index"); err == nil {
+		t.Fatal("expect a parse error")
+	}
+	tmpl, _ = Parse("{{.Random 0}}")
+	if _, err := tmpl.Next(1, "0xabc"); err == nil {
+		t.Fatal("expect a random length error")
+	}
+	if IsTemplate("data:,hello") || !IsTemplate("data:,{{.Index}}") {
+		t.Fatal("IsTemplate")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"inscription/app"
 	"inscription/chain/eth/core"
 	"inscription/chain/util"
+	"inscription/config"
 	"testing"
 )
 
@@ -34,7 +35,7 @@ func TestMint(t *testing.T) {
 	data := util.TextToHex(text)
 	gasLimit := "210000"
 	gasPrice := "30000000000" // in wei (30 gwei)
-	hash, err := evmApp.Inscribe(account.PrivateKey, data, gasPrice, gasLimit)
+	hash, err := evmApp.Inscribe(account.PrivateKey, "", data, gasPrice, gasLimit, "")
 	if err != nil {
 		t.Log(err)
 		return
@@ -64,7 +65,8 @@ func TestTransfer(t *testing.T) {
 	}
 	t.Logf("the balance: %s", balance.DivRound(accuracyEth, 4))
 
-	hash, err := evmApp.Transfer(account, "0x0b39fb6bce3381115db85210666585ebb9d32e25", "10000")
+	fee := &config.Fee{FeeMode: config.FeeModeLegacy, GasPrice: "30000000000"} // in wei (30 gwei)
+	hash, err := evmApp.Transfer(account, "0x0b39fb6bce3381115db85210666585ebb9d32e25", "10000", fee)
 	if err != nil {
 		t.Log(err)
 		return